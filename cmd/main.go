@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/config"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/handlers"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/routes"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
 	"github.com/gin-gonic/gin"
@@ -30,19 +32,43 @@ func main() {
 	router.Use(middleware.ErrorMiddleware())
 
 	// Services & Handlers
-	applicantService, schemeService, applicationService := initializeServices()
+	applicantService, schemeService, applicationService, auditService, purgeService, ledgerService, eventDispatcher, webhookService, idempotencyService := initializeServices()
 	applicantHandler := handlers.NewApplicantHandler(applicantService)
 	schemeHandler := handlers.NewSchemeHandler(schemeService)
 	applicationHandler := handlers.NewApplicationHandler(applicationService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	purgeHandler := handlers.NewPurgeHandler(purgeService)
+	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
 
 	// Routes
-	routes.SetupRoutes(router, applicantHandler, schemeHandler, applicationHandler)
+	routes.SetupRoutes(router, config.NewAuthenticator(), idempotencyService, applicantHandler, schemeHandler, applicationHandler, auditHandler, purgeHandler, ledgerHandler, webhookHandler)
 
 	srv := &http.Server{
 		Addr:    ":" + getPort(),
 		Handler: router,
 	}
 
+	// Schedule due benefit installments in the background
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	if interval := config.PayoutScheduleInterval(); interval > 0 {
+		go ledgerService.RunPayoutScheduler(schedulerCtx, interval)
+	}
+
+	// Deliver domain events from the outbox to the event sinks
+	if interval := config.EventDispatchInterval(); interval > 0 {
+		go eventDispatcher.RunDispatcher(schedulerCtx, interval)
+	}
+
+	// Send queued webhook deliveries
+	if interval := config.WebhookDeliveryInterval(); interval > 0 {
+		go webhookService.RunDeliveryWorker(schedulerCtx, interval)
+	}
+
+	// Delete expired idempotency keys
+	go idempotencyService.RunCleanup(schedulerCtx, data.IDEMPOTENCY_CLEANUP_INTERVAL)
+
 	//run server
 	go func() {
 		log.Printf("Server is running on port %s", getPort())
@@ -51,14 +77,21 @@ func main() {
 		}
 	}()
 
-	shutdown(srv)
+	shutdown(srv, stopScheduler)
 }
 
-func initializeServices() (*services.ApplicantService, *services.SchemeService, *services.ApplicationService) {
-	applicantService := services.NewApplicantService(config.DB)
-	schemeService := services.NewSchemeService(config.DB)
-	applicationService := services.NewApplicationService(config.DB)
-	return applicantService, schemeService, applicationService
+func initializeServices() (*services.ApplicantService, *services.SchemeService, *services.ApplicationService, *services.AuditService, *services.PurgeService, *services.LedgerService, *services.EventDispatcher, *services.WebhookService, *services.IdempotencyService) {
+	store := repository.NewGormStore(config.DB)
+	applicantService := services.NewApplicantService(store)
+	schemeService := services.NewSchemeService(store)
+	applicationService := services.NewApplicationService(store)
+	auditService := services.NewAuditService(store)
+	purgeService := services.NewPurgeService(store, config.DeletedRetention())
+	ledgerService := services.NewLedgerService(store)
+	eventDispatcher := services.NewEventDispatcher(store, config.EventSinks(store))
+	webhookService := services.NewWebhookService(store)
+	idempotencyService := services.NewIdempotencyService(store)
+	return applicantService, schemeService, applicationService, auditService, purgeService, ledgerService, eventDispatcher, webhookService, idempotencyService
 }
 
 func getPort() string {
@@ -69,11 +102,12 @@ func getPort() string {
 	return port
 }
 
-func shutdown(srv *http.Server) {
+func shutdown(srv *http.Server, stopScheduler context.CancelFunc) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopScheduler()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
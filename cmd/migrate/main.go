@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/config"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/migrations"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: go run ./cmd/migrate [-dir DIR] <command> [args]
+
+Commands:
+  up           apply all pending migrations
+  down [N]     roll back the last N applied migrations (default 1)
+  status       list migrations and whether they have been applied
+  create NAME  add empty up/down files for a new migration for every database driver
+
+The database is selected with the same environment variables as the server (DB_DRIVER, DB_HOST, ...).
+`
+
+func main() {
+	dir := flag.String("dir", "internal/migrations", "migrations directory used by create")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using the environment")
+	}
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		up()
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			n, err := strconv.Atoi(flag.Arg(1))
+			if err != nil || n < 1 {
+				log.Fatalf("Invalid number of migrations to roll back: %s", flag.Arg(1))
+			}
+			steps = n
+		}
+		down(steps)
+	case "status":
+		status()
+	case "create":
+		if flag.NArg() < 2 {
+			log.Fatal("Migration name is required")
+		}
+		create(*dir, flag.Arg(1))
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func up() {
+	applied, err := migrations.Up(config.OpenDatabase())
+	for _, migration := range applied {
+		log.Printf("Applied %04d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(applied) == 0 {
+		log.Println("Database schema is up to date")
+	}
+}
+
+func down(steps int) {
+	rolledBack, err := migrations.Down(config.OpenDatabase(), steps)
+	for _, migration := range rolledBack {
+		log.Printf("Rolled back %04d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(rolledBack) == 0 {
+		log.Println("No migrations to roll back")
+	}
+}
+
+func status() {
+	statuses, err := migrations.Status(config.OpenDatabase())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%04d  %-40s  %s\n", status.Version, status.Name, appliedAt)
+	}
+}
+
+func create(dir, name string) {
+	created, err := migrations.Create(dir, name)
+	for _, path := range created {
+		log.Printf("Created %s", path)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/joho/godotenv"
+)
+
+// Issues an HS256 token signed with AUTH_JWT_SECRET for local development and testing
+func main() {
+	subject := flag.String("sub", "", "actor the token is issued to (required)")
+	roles := flag.String("roles", data.ROLE_CASEWORKER, "comma separated roles: admin, caseworker, auditor")
+	ttl := flag.Duration("ttl", 8*time.Hour, "token lifetime")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using the environment")
+	}
+
+	secret := os.Getenv("AUTH_JWT_SECRET")
+	if secret == "" {
+		log.Fatal("AUTH_JWT_SECRET is not set")
+	}
+
+	if *subject == "" {
+		log.Fatal("-sub is required")
+	}
+
+	validRoles := map[string]bool{
+		data.ROLE_ADMIN:      true,
+		data.ROLE_CASEWORKER: true,
+		data.ROLE_AUDITOR:    true,
+	}
+
+	roleList := strings.Split(*roles, ",")
+	for i, role := range roleList {
+		roleList[i] = strings.TrimSpace(role)
+		if !validRoles[roleList[i]] {
+			log.Fatalf("Invalid role: %s", roleList[i])
+		}
+	}
+
+	now := time.Now()
+	claims := middleware.Claims{
+		Roles: roleList,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   *subject,
+			Issuer:    os.Getenv("AUTH_ISSUER"),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(*ttl)),
+		},
+	}
+	if audience := os.Getenv("AUTH_AUDIENCE"); audience != "" {
+		claims.Audience = jwt.ClaimStrings{audience}
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+
+	fmt.Println(token)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+)
+
+// Receives webhooks on a local port for development and testing, verifying their signatures
+// and printing the events. -fail makes it answer the first requests with 500 to exercise retries.
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	secret := flag.String("secret", "", "secret of the webhook subscription (required)")
+	fail := flag.Int64("fail", 0, "number of requests to fail with 500 before accepting")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "largest accepted age of a request's timestamp")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Fatal("-secret is required")
+	}
+
+	var received atomic.Int64
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(data.WEBHOOK_HEADER_TIMESTAMP), 10, 64)
+		if err != nil || time.Since(time.Unix(timestamp, 0)).Abs() > *tolerance {
+			log.Printf("Rejected delivery %s: missing or stale timestamp", r.Header.Get(data.WEBHOOK_HEADER_DELIVERY))
+			http.Error(w, "invalid timestamp", http.StatusUnauthorized)
+			return
+		}
+
+		if !utils.VerifyWebhook(*secret, timestamp, body, r.Header.Get(data.WEBHOOK_HEADER_SIGNATURE)) {
+			log.Printf("Rejected delivery %s: invalid signature", r.Header.Get(data.WEBHOOK_HEADER_DELIVERY))
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		if n := received.Add(1); n <= *fail {
+			log.Printf("Failing delivery %s of %s on purpose (%d of %d)", r.Header.Get(data.WEBHOOK_HEADER_DELIVERY), r.Header.Get(data.WEBHOOK_HEADER_EVENT), n, *fail)
+			http.Error(w, "failing on purpose", http.StatusInternalServerError)
+			return
+		}
+
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err != nil {
+			pretty.Write(body)
+		}
+		log.Printf("Received %s (event %s, delivery %s):\n%s", r.Header.Get(data.WEBHOOK_HEADER_EVENT), r.Header.Get(data.WEBHOOK_HEADER_EVENT_ID), r.Header.Get(data.WEBHOOK_HEADER_DELIVERY), pretty.String())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("Webhook receiver listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
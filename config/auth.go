@@ -0,0 +1,43 @@
+package config
+
+import (
+	"log"
+	"os"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
+)
+
+// NewAuthenticator verifies tokens with AUTH_JWT_SECRET (HS256) or the keys in AUTH_JWKS_FILE
+func NewAuthenticator() *middleware.Authenticator {
+	secret := os.Getenv("AUTH_JWT_SECRET")
+	jwksFile := os.Getenv("AUTH_JWKS_FILE")
+
+	opts := middleware.AuthOptions{
+		Issuer:   os.Getenv("AUTH_ISSUER"),
+		Audience: os.Getenv("AUTH_AUDIENCE"),
+	}
+
+	var authenticator *middleware.Authenticator
+	var err error
+
+	switch {
+	case secret != "" && jwksFile != "":
+		log.Fatal("Set either AUTH_JWT_SECRET or AUTH_JWKS_FILE, not both")
+	case secret != "":
+		authenticator, err = middleware.NewHMACAuthenticator([]byte(secret), opts)
+	case jwksFile != "":
+		jwks, readErr := os.ReadFile(jwksFile)
+		if readErr != nil {
+			log.Fatalf("Failed to read AUTH_JWKS_FILE: %v", readErr)
+		}
+		authenticator, err = middleware.NewJWKSAuthenticator(jwks, opts)
+	default:
+		log.Fatal("Authentication is not configured, set AUTH_JWT_SECRET or AUTH_JWKS_FILE")
+	}
+
+	if err != nil {
+		log.Fatalf("Failed to configure authentication: %v", err)
+	}
+
+	return authenticator
+}
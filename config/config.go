@@ -5,27 +5,59 @@ import (
 	"log"
 	"os"
 
-	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/migrations"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var DB *gorm.DB
 
-func migrateDatabase(db *gorm.DB) {
-	log.Println("Running Database Migration...")
+// ensureSchemaCurrent stops the server when migrations are pending, see cmd/migrate
+func ensureSchemaCurrent(db *gorm.DB) {
+	pending, err := migrations.Pending(db)
+	if err != nil {
+		log.Fatalf("Failed to check database migrations: %v", err)
+	}
 
-	// AutoMigrate all models
-	for _, model := range models.Models {
-		if err := db.AutoMigrate(model); err != nil {
-			log.Fatalf("Migration failed for %T: %v", model, err)
+	if len(pending) > 0 {
+		for _, migration := range pending {
+			log.Printf("Pending migration: %04d_%s", migration.Version, migration.Name)
 		}
+		log.Fatalf("Database schema is behind by %d migration(s), run 'go run ./cmd/migrate up' first", len(pending))
+	}
+}
+
+// OpenDatabase connects to the database selected by DB_DRIVER without checking its schema
+func OpenDatabase() *gorm.DB {
+	var dialector gorm.Dialector
+
+	switch driver := os.Getenv("DB_DRIVER"); driver {
+	case "", "postgres":
+		dialector = openPostgres()
+	case "sqlite":
+		dialector = openSQLite()
+	default:
+		log.Fatalf("Unsupported DB_DRIVER %q, must be 'postgres' or 'sqlite'", driver)
 	}
 
-	log.Println("Database Migration Completed Successfully!")
+	database, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		log.Fatal("Failed to connect to the database:", err)
+	}
+
+	return database
 }
 
 func ConnectDatabase() {
+	database := OpenDatabase()
+
+	ensureSchemaCurrent(database)
+
+	DB = database
+	log.Printf("Database connected successfully! (%s)", database.Dialector.Name())
+}
+
+func openPostgres() gorm.Dialector {
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		os.Getenv("DB_HOST"),
@@ -35,15 +67,5 @@ func ConnectDatabase() {
 		os.Getenv("DB_PORT"),
 	)
 
-	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Fatal("Failed to connect to the database:", err)
-	}
-
-	database.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp";`)
-
-	migrateDatabase(database)
-
-	DB = database
-	log.Println("Database connected successfully!")
+	return postgres.Open(dsn)
 }
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
+)
+
+const (
+	defaultEventSinks             = data.EVENT_SINK_LOG + "," + data.EVENT_SINK_WEBHOOK
+	defaultEventDispatchSeconds   = 5
+	defaultWebhookDeliverySeconds = 5
+)
+
+// EventSinks are the sinks named in the comma separated EVENT_SINKS, "log,webhook" by default.
+// The "file" sink appends to EVENT_SINK_FILE, the "webhook" sink queues deliveries in store.
+func EventSinks(store repository.Store) []services.EventSink {
+	names := os.Getenv("EVENT_SINKS")
+	if names == "" {
+		names = defaultEventSinks
+	}
+
+	sinks := []services.EventSink{}
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case data.EVENT_SINK_LOG:
+			sinks = append(sinks, services.LogEventSink{})
+		case data.EVENT_SINK_FILE:
+			path := os.Getenv("EVENT_SINK_FILE")
+			if path == "" {
+				log.Fatal("EVENT_SINK_FILE must be set to use the file event sink")
+			}
+			sinks = append(sinks, services.NewFileEventSink(path))
+		case data.EVENT_SINK_WEBHOOK:
+			sinks = append(sinks, services.WebhookEventSink{Store: store})
+		default:
+			log.Fatalf("Unsupported event sink %q in EVENT_SINKS, must be 'log', 'file' or 'webhook'", name)
+		}
+	}
+
+	return sinks
+}
+
+// EventDispatchInterval is how often pending events are dispatched, set in seconds with
+// EVENT_DISPATCH_SECONDS. Zero disables the dispatcher.
+func EventDispatchInterval() time.Duration {
+	seconds := defaultEventDispatchSeconds
+
+	if value := os.Getenv("EVENT_DISPATCH_SECONDS"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			log.Fatalf("EVENT_DISPATCH_SECONDS must be zero or a positive number of seconds, got %q", value)
+		}
+		seconds = parsed
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// WebhookDeliveryInterval is how often queued webhook deliveries are sent, set in seconds with
+// WEBHOOK_DELIVERY_SECONDS. Zero disables the delivery worker.
+func WebhookDeliveryInterval() time.Duration {
+	seconds := defaultWebhookDeliverySeconds
+
+	if value := os.Getenv("WEBHOOK_DELIVERY_SECONDS"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			log.Fatalf("WEBHOOK_DELIVERY_SECONDS must be zero or a positive number of seconds, got %q", value)
+		}
+		seconds = parsed
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultDeletedRetentionDays = 365
+
+// DeletedRetention is how long soft deleted records are kept before they can be purged, set with DELETED_RETENTION_DAYS
+func DeletedRetention() time.Duration {
+	days := defaultDeletedRetentionDays
+
+	if value := os.Getenv("DELETED_RETENTION_DAYS"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			log.Fatalf("DELETED_RETENTION_DAYS must be a positive number of days, got %q", value)
+		}
+		days = parsed
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultPayoutScheduleMinutes = 60
+
+// PayoutScheduleInterval is how often due benefit installments are scheduled for payout, set in minutes
+// with PAYOUT_SCHEDULE_MINUTES. Zero disables the scheduler.
+func PayoutScheduleInterval() time.Duration {
+	minutes := defaultPayoutScheduleMinutes
+
+	if value := os.Getenv("PAYOUT_SCHEDULE_MINUTES"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			log.Fatalf("PAYOUT_SCHEDULE_MINUTES must be zero or a positive number of minutes, got %q", value)
+		}
+		minutes = parsed
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
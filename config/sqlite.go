@@ -0,0 +1,21 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+const defaultSQLitePath = "fas.db"
+
+func openSQLite() gorm.Dialector {
+	path := os.Getenv("DB_PATH")
+	if path == "" {
+		path = defaultSQLitePath
+	}
+
+	dsn := fmt.Sprintf("%s?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", path)
+	return sqlite.Open(dsn)
+}
@@ -0,0 +1,24 @@
+package data
+
+// Application Status Constants
+const (
+	APPLICATION_STATUS_SUBMITTED    = "submitted"
+	APPLICATION_STATUS_UNDER_REVIEW = "under_review"
+	APPLICATION_STATUS_APPROVED     = "approved"
+	APPLICATION_STATUS_REJECTED     = "rejected"
+	APPLICATION_STATUS_WITHDRAWN    = "withdrawn"
+)
+
+// Allowed status transitions, keyed by the current status.
+// Approved, rejected and withdrawn are terminal.
+var APPLICATION_STATUS_TRANSITION_MAP = map[string][]string{
+	APPLICATION_STATUS_SUBMITTED: {
+		APPLICATION_STATUS_UNDER_REVIEW,
+		APPLICATION_STATUS_WITHDRAWN,
+	},
+	APPLICATION_STATUS_UNDER_REVIEW: {
+		APPLICATION_STATUS_APPROVED,
+		APPLICATION_STATUS_REJECTED,
+		APPLICATION_STATUS_WITHDRAWN,
+	},
+}
@@ -0,0 +1,37 @@
+package data
+
+const (
+	AUDIT_ENTITY_APPLICANT        = "applicant"
+	AUDIT_ENTITY_SCHEME           = "scheme"
+	AUDIT_ENTITY_APPLICATION      = "application"
+	AUDIT_ENTITY_DISBURSEMENT     = "disbursement"
+	AUDIT_ENTITY_WEBHOOK          = "webhook"
+	AUDIT_ENTITY_WEBHOOK_DELIVERY = "webhook_delivery"
+)
+
+const (
+	AUDIT_ACTION_CREATE        = "create"
+	AUDIT_ACTION_UPDATE        = "update"
+	AUDIT_ACTION_DELETE        = "delete"
+	AUDIT_ACTION_STATUS_CHANGE = "status_change"
+	AUDIT_ACTION_RESTORE       = "restore"
+	AUDIT_ACTION_PURGE         = "purge"
+)
+
+var AUDIT_ENTITY_TYPES = map[string]bool{
+	AUDIT_ENTITY_APPLICANT:        true,
+	AUDIT_ENTITY_SCHEME:           true,
+	AUDIT_ENTITY_APPLICATION:      true,
+	AUDIT_ENTITY_DISBURSEMENT:     true,
+	AUDIT_ENTITY_WEBHOOK:          true,
+	AUDIT_ENTITY_WEBHOOK_DELIVERY: true,
+}
+
+var AUDIT_ACTIONS = map[string]bool{
+	AUDIT_ACTION_CREATE:        true,
+	AUDIT_ACTION_UPDATE:        true,
+	AUDIT_ACTION_DELETE:        true,
+	AUDIT_ACTION_STATUS_CHANGE: true,
+	AUDIT_ACTION_RESTORE:       true,
+	AUDIT_ACTION_PURGE:         true,
+}
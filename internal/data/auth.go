@@ -0,0 +1,7 @@
+package data
+
+const (
+	ROLE_ADMIN      = "admin"
+	ROLE_CASEWORKER = "caseworker"
+	ROLE_AUDITOR    = "auditor"
+)
@@ -0,0 +1,28 @@
+package data
+
+import "time"
+
+// Domain event types are "<entity type>.<past tense of the audit action>", e.g. applicant.created
+var EVENT_ACTION_MAP = map[string]string{
+	AUDIT_ACTION_CREATE:        "created",
+	AUDIT_ACTION_UPDATE:        "updated",
+	AUDIT_ACTION_DELETE:        "deleted",
+	AUDIT_ACTION_STATUS_CHANGE: "status_changed",
+	AUDIT_ACTION_RESTORE:       "restored",
+	AUDIT_ACTION_PURGE:         "purged",
+}
+
+const (
+	EVENT_SINK_LOG     = "log"
+	EVENT_SINK_FILE    = "file"
+	EVENT_SINK_WEBHOOK = "webhook" // queues a delivery for every matching webhook subscription
+)
+
+const (
+	// Events claimed by a dispatcher are hidden from the others for the lease
+	OUTBOX_LEASE          = time.Minute
+	OUTBOX_BATCH_SIZE     = 100
+	OUTBOX_RETRY_BASE     = 5 * time.Second
+	OUTBOX_RETRY_MAX      = time.Hour
+	OUTBOX_DISPATCHED_TTL = 7 * 24 * time.Hour
+)
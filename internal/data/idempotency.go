@@ -0,0 +1,18 @@
+package data
+
+import "time"
+
+// Headers of a POST request that may be retried, and of a response replayed for a retry
+const (
+	IDEMPOTENCY_KEY_HEADER      = "Idempotency-Key"
+	IDEMPOTENCY_REPLAYED_HEADER = "Idempotent-Replayed"
+)
+
+const (
+	IDEMPOTENCY_MAX_KEY_LENGTH = 255
+	// A request in progress holds its key for the lease, a retry after it runs the request again
+	IDEMPOTENCY_LEASE = time.Minute
+	// Keys can be reused for any request once they expire
+	IDEMPOTENCY_KEY_TTL          = 24 * time.Hour
+	IDEMPOTENCY_CLEANUP_INTERVAL = time.Hour
+)
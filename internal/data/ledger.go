@@ -0,0 +1,22 @@
+package data
+
+// Disbursement Ledger Entry Types
+const (
+	LEDGER_ENTRY_SCHEDULED = "scheduled"
+	LEDGER_ENTRY_PAID      = "paid"
+	LEDGER_ENTRY_FAILED    = "failed"
+	LEDGER_ENTRY_REVERSED  = "reversed"
+)
+
+// Entries allowed to follow the latest entry of a disbursement, keyed by its type.
+// Failed and reversed disbursements are final; a new disbursement is scheduled instead.
+var LEDGER_ENTRY_TRANSITION_MAP = map[string][]string{
+	LEDGER_ENTRY_SCHEDULED: {
+		LEDGER_ENTRY_PAID,
+		LEDGER_ENTRY_FAILED,
+		LEDGER_ENTRY_REVERSED,
+	},
+	LEDGER_ENTRY_PAID: {
+		LEDGER_ENTRY_REVERSED,
+	},
+}
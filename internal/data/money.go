@@ -0,0 +1,22 @@
+package data
+
+// Currency Constants
+const DEFAULT_CURRENCY = "SGD"
+
+// ISO 4217 codes accepted for schemes. Amounts are stored in minor units with two decimal places,
+// so only currencies with exactly two minor digits are listed.
+var CURRENCY_CODES = map[string]bool{
+	"SGD": true,
+	"USD": true,
+	"EUR": true,
+	"GBP": true,
+	"AUD": true,
+	"HKD": true,
+	"MYR": true,
+}
+
+// Amount Limits, in minor units
+const (
+	MAX_BENEFIT_AMOUNT = 1_000_000_00      // 1,000,000.00 per benefit
+	MAX_SCHEME_BUDGET  = 10_000_000_000_00 // 10,000,000,000.00 per scheme
+)
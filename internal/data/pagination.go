@@ -0,0 +1,40 @@
+package data
+
+const (
+	SORT_ORDER_ASC  = "asc"
+	SORT_ORDER_DESC = "desc"
+
+	SORT_CREATED_AT    = "created_at"
+	SORT_UPDATED_AT    = "updated_at"
+	SORT_NAME          = "name"
+	SORT_DATE_OF_BIRTH = "date_of_birth"
+	SORT_STATUS        = "status"
+)
+
+var APPLICANT_SORT_FIELDS = map[string]bool{
+	SORT_CREATED_AT:    true,
+	SORT_UPDATED_AT:    true,
+	SORT_NAME:          true,
+	SORT_DATE_OF_BIRTH: true,
+}
+
+var SCHEME_SORT_FIELDS = map[string]bool{
+	SORT_CREATED_AT: true,
+	SORT_UPDATED_AT: true,
+	SORT_NAME:       true,
+}
+
+var APPLICATION_SORT_FIELDS = map[string]bool{
+	SORT_CREATED_AT: true,
+	SORT_UPDATED_AT: true,
+	SORT_STATUS:     true,
+}
+
+var AUDIT_SORT_FIELDS = map[string]bool{
+	SORT_CREATED_AT: true,
+}
+
+var WEBHOOK_DELIVERY_SORT_FIELDS = map[string]bool{
+	SORT_CREATED_AT: true,
+	SORT_UPDATED_AT: true,
+}
@@ -0,0 +1,39 @@
+package data
+
+// Benefit Recurrence Frequencies
+const (
+	BENEFIT_FREQUENCY_WEEKLY      = "weekly"
+	BENEFIT_FREQUENCY_FORTNIGHTLY = "fortnightly"
+	BENEFIT_FREQUENCY_MONTHLY     = "monthly"
+	BENEFIT_FREQUENCY_QUARTERLY   = "quarterly"
+	BENEFIT_FREQUENCY_YEARLY      = "yearly"
+)
+
+// Days between installments of fixed length frequencies
+var BENEFIT_FREQUENCY_DAYS_MAP = map[string]int{
+	BENEFIT_FREQUENCY_WEEKLY:      7,
+	BENEFIT_FREQUENCY_FORTNIGHTLY: 14,
+}
+
+// Months between installments of calendar frequencies
+var BENEFIT_FREQUENCY_MONTHS_MAP = map[string]int{
+	BENEFIT_FREQUENCY_MONTHLY:   1,
+	BENEFIT_FREQUENCY_QUARTERLY: 3,
+	BENEFIT_FREQUENCY_YEARLY:    12,
+}
+
+// Most installments a benefit can have, which also bounds schedules that only set an end date
+const MAX_BENEFIT_INSTALLMENTS = 520
+
+// Installment Statuses of installments without a disbursement.
+// Installments with one take the status of their latest disbursement.
+const (
+	INSTALLMENT_STATUS_UPCOMING = "upcoming"
+	INSTALLMENT_STATUS_DUE      = "due"
+)
+
+// Days ahead the installment preview covers when no end date is given
+const INSTALLMENT_PREVIEW_DAYS = 90
+
+// Actor recorded for disbursements scheduled by the payout scheduler
+const PAYOUT_SCHEDULER_ACTOR = "scheduler"
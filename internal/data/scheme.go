@@ -37,3 +37,19 @@ var CRITERIA_TYPE_ID_MAP = map[int]string{
 	CRITERIA_ABOVE:          ">",
 	CRITERIA_BELOW:          "<",
 }
+
+// Criteria Tree Constants
+const CRITERIA_VERSION = 2
+
+const (
+	CRITERIA_NODE_AND               = "and"
+	CRITERIA_NODE_OR                = "or"
+	CRITERIA_NODE_NOT               = "not"
+	CRITERIA_NODE_EMPLOYMENT_STATUS = "employment_status"
+	CRITERIA_NODE_SEX               = "sex"
+	CRITERIA_NODE_HAS_CHILDREN      = "has_children"
+	CRITERIA_NODE_HOUSEHOLD_SIZE    = "household_size"
+	CRITERIA_NODE_HAS_RELATION      = "has_relation"
+	CRITERIA_NODE_APPLICANT_AGE     = "applicant_age"
+	CRITERIA_NODE_MEMBER_AGE        = "household_member_age"
+)
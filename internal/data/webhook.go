@@ -0,0 +1,37 @@
+package data
+
+import "time"
+
+// Webhook Delivery Statuses
+const (
+	WEBHOOK_DELIVERY_PENDING   = "pending"
+	WEBHOOK_DELIVERY_DELIVERED = "delivered"
+	WEBHOOK_DELIVERY_DEAD      = "dead" // gave up after WEBHOOK_MAX_ATTEMPTS, can be redelivered
+)
+
+var WEBHOOK_DELIVERY_STATUSES = map[string]bool{
+	WEBHOOK_DELIVERY_PENDING:   true,
+	WEBHOOK_DELIVERY_DELIVERED: true,
+	WEBHOOK_DELIVERY_DEAD:      true,
+}
+
+// Headers of a webhook request. The signature is "sha256=" and the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the subscription's secret.
+const (
+	WEBHOOK_HEADER_EVENT     = "X-Webhook-Event"
+	WEBHOOK_HEADER_EVENT_ID  = "X-Webhook-Event-Id"
+	WEBHOOK_HEADER_DELIVERY  = "X-Webhook-Delivery"
+	WEBHOOK_HEADER_TIMESTAMP = "X-Webhook-Timestamp"
+	WEBHOOK_HEADER_SIGNATURE = "X-Webhook-Signature"
+)
+
+const (
+	WEBHOOK_MAX_ATTEMPTS      = 8
+	WEBHOOK_RETRY_BASE        = 10 * time.Second
+	WEBHOOK_RETRY_MAX         = time.Hour
+	WEBHOOK_TIMEOUT           = 10 * time.Second
+	WEBHOOK_LEASE             = time.Minute // longer than WEBHOOK_TIMEOUT
+	WEBHOOK_BATCH_SIZE        = 50
+	WEBHOOK_MIN_SECRET_LENGTH = 16
+	WEBHOOK_MAX_ERROR_LENGTH  = 500
+)
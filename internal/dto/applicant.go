@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+
 type HouseholdMember struct {
 	ID               string `json:"id"`
 	Name             string `json:"name"`
@@ -22,3 +24,42 @@ type ApplicantWithHousehold struct {
 	Applicant
 	Household []HouseholdMember `json:"household"`
 }
+
+func ApplicantWithHouseholdFromModel(applicant models.Applicant) ApplicantWithHousehold {
+	householdDTO := make([]HouseholdMember, len(applicant.Household))
+	for i, member := range applicant.Household {
+		householdDTO[i] = HouseholdMember{
+			ID:               member.ID,
+			Name:             member.Name,
+			EmploymentStatus: member.EmploymentStatus,
+			Sex:              member.Sex,
+			DateOfBirth:      member.DateOfBirth,
+			Relation:         member.Relation,
+		}
+	}
+
+	return ApplicantWithHousehold{
+		Applicant: Applicant{
+			ID:               applicant.ID,
+			Name:             applicant.Name,
+			EmploymentStatus: applicant.EmploymentStatus,
+			Sex:              applicant.Sex,
+			DateOfBirth:      applicant.DateOfBirth,
+		},
+		Household: householdDTO,
+	}
+}
+
+// NameMatch is a name that matched an applicant search. HouseholdMemberID is set when
+// the name belongs to a household member rather than the applicant.
+type NameMatch struct {
+	Name              string  `json:"name"`
+	HouseholdMemberID string  `json:"household_member_id,omitempty"`
+	Score             float64 `json:"score"`
+}
+
+type ApplicantSearchResult struct {
+	Applicant ApplicantWithHousehold `json:"applicant"`
+	Score     float64                `json:"score"`
+	Matches   []NameMatch            `json:"matches"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// CriterionFailure describes a single scheme criterion an applicant did not meet
+type CriterionFailure struct {
+	Criterion string `json:"criterion"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual"`
+}
+
+// EligibilityOverride lets staff register an application the applicant is not eligible for
+type EligibilityOverride struct {
+	Actor  string
+	Reason string
+}
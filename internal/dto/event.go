@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+// Event is a domain event as delivered to the event sinks. ID is unique per change, so
+// consumers can discard an event delivered more than once.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Actor      string          `json:"actor"`
+	Data       models.Snapshot `json:"data"`
+	Previous   models.Snapshot `json:"previous,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+func EventFromModel(event models.OutboxEvent) Event {
+	return Event{
+		ID:         event.ID,
+		Type:       event.Type,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Actor:      event.Actor,
+		Data:       event.Data,
+		Previous:   event.Previous,
+		OccurredAt: event.CreatedAt,
+	}
+}
+
+// DispatchRun reports one pass of the event dispatcher
+type DispatchRun struct {
+	Dispatched int `json:"dispatched"`
+	Failed     int `json:"failed"`
+}
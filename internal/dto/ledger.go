@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+// LedgerFilter selects ledger entries, empty fields are not filtered on
+type LedgerFilter struct {
+	ApplicationID string
+	ApplicantID   string
+	SchemeID      string
+}
+
+// Disbursement is the payout of one benefit of an approved application, built from its ledger entries.
+// Status is the type of its latest entry.
+type Disbursement struct {
+	ID            string               `json:"id"`
+	ApplicationID string               `json:"application_id"`
+	ApplicantID   string               `json:"applicant_id"`
+	SchemeID      string               `json:"scheme_id"`
+	BenefitID     string               `json:"benefit_id"`
+	BenefitName   string               `json:"benefit_name"`
+	Installment   int                  `json:"installment"`
+	DueDate       string               `json:"due_date"`
+	Amount        models.Money         `json:"amount"`
+	Currency      string               `json:"currency"`
+	Status        string               `json:"status"`
+	Entries       []models.LedgerEntry `json:"entries"`
+}
+
+// LedgerBalance totals the amounts of disbursements in one currency by their current status
+type LedgerBalance struct {
+	Currency      string       `json:"currency"`
+	Scheduled     models.Money `json:"scheduled"`
+	Paid          models.Money `json:"paid"`
+	Failed        models.Money `json:"failed"`
+	Reversed      models.Money `json:"reversed"`
+	Disbursements int          `json:"disbursements"`
+}
+
+// Installment is one payment in the schedule of a benefit of an approved application. Status is the status
+// of its latest disbursement, or upcoming or due when it has none.
+type Installment struct {
+	ApplicationID  string       `json:"application_id"`
+	SchemeID       string       `json:"scheme_id"`
+	BenefitID      string       `json:"benefit_id"`
+	BenefitName    string       `json:"benefit_name"`
+	Installment    int          `json:"installment"`
+	DueDate        string       `json:"due_date"`
+	Amount         models.Money `json:"amount"`
+	Currency       string       `json:"currency"`
+	Status         string       `json:"status"`
+	DisbursementID string       `json:"disbursement_id,omitempty"`
+}
+
+// PayoutRun is the result of scheduling the disbursements of due installments
+type PayoutRun struct {
+	AsOf          string         `json:"as_of"`
+	Disbursements []Disbursement `json:"disbursements"`
+	// Applications whose installments could not be scheduled, they are retried on the next run
+	FailedApplications []string `json:"failed_applications"`
+}
+
+// DisbursementsFromEntries groups entries by disbursement in the order the disbursements were scheduled.
+// Entries must be ordered by sequence within each disbursement.
+func DisbursementsFromEntries(entries []models.LedgerEntry) []Disbursement {
+	disbursements := []Disbursement{}
+	index := map[string]int{}
+
+	for _, entry := range entries {
+		i, ok := index[entry.DisbursementID]
+		if !ok {
+			i = len(disbursements)
+			index[entry.DisbursementID] = i
+			disbursements = append(disbursements, Disbursement{
+				ID:            entry.DisbursementID,
+				ApplicationID: entry.ApplicationID,
+				ApplicantID:   entry.ApplicantID,
+				SchemeID:      entry.SchemeID,
+				BenefitID:     entry.BenefitID,
+				BenefitName:   entry.BenefitName,
+				Installment:   entry.Installment,
+				DueDate:       entry.DueDate,
+				Amount:        entry.Amount,
+				Currency:      entry.Currency,
+			})
+		}
+
+		disbursements[i].Status = entry.Type
+		disbursements[i].Entries = append(disbursements[i].Entries, entry)
+	}
+
+	return disbursements
+}
+
+// LedgerBalancesFromDisbursements totals disbursements per currency, since amounts in
+// different currencies cannot be added. Currencies are in the order they first appear.
+func LedgerBalancesFromDisbursements(disbursements []Disbursement) []LedgerBalance {
+	balances := []LedgerBalance{}
+	index := map[string]int{}
+
+	for _, disbursement := range disbursements {
+		i, ok := index[disbursement.Currency]
+		if !ok {
+			i = len(balances)
+			index[disbursement.Currency] = i
+			balances = append(balances, LedgerBalance{Currency: disbursement.Currency})
+		}
+
+		balance := &balances[i]
+		balance.Disbursements++
+		switch disbursement.Status {
+		case data.LEDGER_ENTRY_SCHEDULED:
+			balance.Scheduled += disbursement.Amount
+		case data.LEDGER_ENTRY_PAID:
+			balance.Paid += disbursement.Amount
+		case data.LEDGER_ENTRY_FAILED:
+			balance.Failed += disbursement.Amount
+		case data.LEDGER_ENTRY_REVERSED:
+			balance.Reversed += disbursement.Amount
+		}
+	}
+	return balances
+}
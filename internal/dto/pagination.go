@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+type Pagination struct {
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+	Total    int64 `json:"total"`
+}
+
+// CursorPagination describes a page of a cursor paginated list.
+// NextCursor is empty on the last page.
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// ListOptions selects one page of a sorted list. Cursor is the NextCursor of the previous page.
+type ListOptions struct {
+	Sort   string
+	Desc   bool
+	Limit  int
+	Cursor string
+}
+
+// CreatedRange limits a list to records created at or after From and before Before
+type CreatedRange struct {
+	From   *time.Time
+	Before *time.Time
+}
+
+type ApplicantFilter struct {
+	EmploymentStatus string
+	Sex              string
+	BornFrom         string // YYYY-MM-DD, inclusive
+	BornTo           string // YYYY-MM-DD, inclusive
+	Created          CreatedRange
+}
+
+type SchemeFilter struct {
+	Name    string // case-insensitive substring
+	Created CreatedRange
+}
+
+type ApplicationFilter struct {
+	ApplicantID string
+	SchemeID    string
+	Status      string
+	Created     CreatedRange
+}
+
+type AuditFilter struct {
+	Actor      string
+	EntityType string
+	EntityID   string
+	Action     string
+	Created    CreatedRange
+}
+
+type WebhookDeliveryFilter struct {
+	SubscriptionID string
+	Status         string
+	EventType      string
+	Created        CreatedRange
+}
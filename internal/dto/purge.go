@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// PurgeResult counts the records permanently removed by a purge.
+// Deleted schemes that applications still refer to are kept and counted as skipped.
+type PurgeResult struct {
+	DeletedBefore  time.Time `json:"deleted_before"`
+	Applicants     int       `json:"applicants"`
+	Schemes        int       `json:"schemes"`
+	Applications   int       `json:"applications"`
+	SkippedSchemes int       `json:"skipped_schemes"`
+}
@@ -2,84 +2,306 @@ package dto
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
 )
 
-func ChildrenFromModel(children *models.Children) *Children {
-	if children == nil {
-		return nil
+func CriteriaNodeFromModel(node models.CriteriaNode) CriteriaNode {
+	output := CriteriaNode{
+		Type:  node.Type,
+		Value: node.Value,
 	}
 
-	schoolLevelDisplay := fmt.Sprintf(
-		"%s %s",
-		data.CRITERIA_TYPE_ID_MAP[children.SchoolLevelCondition],
-		data.SCHOOL_LEVEL_TYPE_ID_MAP[children.SchoolLevel],
-	)
+	if node.Condition != 0 {
+		output.Condition = data.CRITERIA_TYPE_ID_MAP[node.Condition]
+	}
 
-	return &Children{
-		SchoolLevel: schoolLevelDisplay,
+	if node.SchoolLevel != 0 {
+		output.SchoolLevel = data.SCHOOL_LEVEL_TYPE_ID_MAP[node.SchoolLevel]
+	}
+
+	if node.Type == data.CRITERIA_NODE_HOUSEHOLD_SIZE {
+		count := node.Count
+		output.Count = &count
 	}
-}
 
-func ChildrenToModel(children *Children) *models.Children {
-	if children == nil {
-		return nil
+	if node.Type == data.CRITERIA_NODE_APPLICANT_AGE || node.Type == data.CRITERIA_NODE_MEMBER_AGE {
+		age := node.Age
+		output.Age = &age
 	}
 
-	var conditionStr, levelStr string
-	fmt.Sscanf(children.SchoolLevel, "%s %s", &conditionStr, &levelStr)
+	for _, child := range node.Nodes {
+		output.Nodes = append(output.Nodes, CriteriaNodeFromModel(child))
+	}
+
+	return output
+}
 
-	// Reverse Mapping for Database Storage
-	schoolLevel := 0
-	for key, value := range data.SCHOOL_LEVEL_TYPE_ID_MAP {
-		if value == levelStr {
-			schoolLevel = key
-			break
+func CriteriaFromModel(criteria models.Criteria) Criteria {
+	output := Criteria{Version: criteria.Version}
+	if criteria.Rule != nil {
+		rule := CriteriaNodeFromModel(*criteria.Rule)
+		output.Rule = &rule
+	}
+	return output
+}
+
+func BenefitsFromModel(benefits []models.Benefit) []Benefit {
+	benefitDTO := make([]Benefit, len(benefits))
+	for i, benefit := range benefits {
+		benefitDTO[i] = Benefit{
+			ID:         benefit.ID,
+			Name:       benefit.Name,
+			Amount:     benefit.Amount,
+			Recurrence: benefit.Recurrence,
+		}
+		if benefit.Formula != nil {
+			formula := AmountFormulaFromModel(*benefit.Formula)
+			benefitDTO[i].Formula = &formula
 		}
 	}
+	return benefitDTO
+}
 
-	conditionVal := 0
-	for key, value := range data.CRITERIA_TYPE_ID_MAP {
-		if value == conditionStr {
-			conditionVal = key
-			break
+func AmountFormulaFromModel(formula models.AmountFormula) AmountFormula {
+	output := AmountFormula{Rules: make([]AmountRule, len(formula.Rules))}
+	for i, rule := range formula.Rules {
+		output.Rules[i] = AmountRule{
+			Member:     CriteriaNodeFromModel(rule.Member),
+			Amount:     rule.Amount,
+			MaxMembers: rule.MaxMembers,
 		}
+		for _, tier := range rule.Tiers {
+			output.Rules[i].Tiers = append(output.Rules[i].Tiers, AmountTier{
+				Member: CriteriaNodeFromModel(tier.Member),
+				Amount: tier.Amount,
+			})
+		}
+	}
+	return output
+}
+
+func SchemeFromModel(scheme models.Scheme) Scheme {
+	output := Scheme{
+		ID:                  scheme.ID,
+		Name:                scheme.Name,
+		Version:             scheme.Version,
+		Criteria:            CriteriaFromModel(scheme.Criteria),
+		Currency:            scheme.Currency,
+		Benefits:            BenefitsFromModel(scheme.Benefits),
+		EffectiveFrom:       scheme.EffectiveFrom,
+		EffectiveTo:         scheme.EffectiveTo,
+		ApplicationDeadline: scheme.ApplicationDeadline,
+		Budget:              scheme.Budget,
+		MaxRecipients:       scheme.MaxRecipients,
+		ReservedBudget:      scheme.ReservedBudget,
+		ReservedRecipients:  scheme.ReservedRecipients,
+	}
+
+	if scheme.Budget != nil {
+		remaining := *scheme.Budget - scheme.ReservedBudget
+		output.RemainingBudget = &remaining
 	}
 
-	return &models.Children{
-		SchoolLevel:          schoolLevel,
-		SchoolLevelCondition: conditionVal,
+	if scheme.MaxRecipients != nil {
+		remaining := *scheme.MaxRecipients - scheme.ReservedRecipients
+		output.RemainingSlots = &remaining
 	}
+
+	return output
 }
 
-func CriteriaFromModel(criteria models.Criteria) Criteria {
-	return Criteria{
-		EmploymentStatus: criteria.EmploymentStatus,
-		HasChildren:      ChildrenFromModel(criteria.HasChildren),
+func SchemeVersionFromModel(version models.SchemeVersion) SchemeVersion {
+	return SchemeVersion{
+		SchemeID:            version.SchemeID,
+		Version:             version.Version,
+		Name:                version.Name,
+		Criteria:            CriteriaFromModel(version.Criteria),
+		Currency:            version.Currency,
+		Benefits:            BenefitsFromModel(version.Benefits),
+		EffectiveFrom:       version.EffectiveFrom,
+		EffectiveTo:         version.EffectiveTo,
+		ApplicationDeadline: version.ApplicationDeadline,
+		Budget:              version.Budget,
+		MaxRecipients:       version.MaxRecipients,
+		CreatedBy:           version.CreatedBy,
+		CreatedAt:           version.CreatedAt,
+	}
+}
+
+// DescribeCriteriaNode renders a criteria node as a human readable expression
+func DescribeCriteriaNode(node models.CriteriaNode) string {
+	condition := data.CRITERIA_TYPE_ID_MAP[node.Condition]
+
+	switch node.Type {
+	case data.CRITERIA_NODE_AND, data.CRITERIA_NODE_OR:
+		parts := make([]string, len(node.Nodes))
+		for i, child := range node.Nodes {
+			parts[i] = DescribeCriteriaNode(child)
+		}
+		return "(" + strings.Join(parts, " "+strings.ToUpper(node.Type)+" ") + ")"
+	case data.CRITERIA_NODE_NOT:
+		if len(node.Nodes) == 0 {
+			return "NOT ()"
+		}
+		return "NOT " + DescribeCriteriaNode(node.Nodes[0])
+	case data.CRITERIA_NODE_EMPLOYMENT_STATUS, data.CRITERIA_NODE_SEX:
+		return fmt.Sprintf("%s == %s", node.Type, node.Value)
+	case data.CRITERIA_NODE_HAS_RELATION:
+		return fmt.Sprintf("has household member with relation %s", node.Value)
+	case data.CRITERIA_NODE_HAS_CHILDREN:
+		return fmt.Sprintf("has child with school level %s %s", condition, data.SCHOOL_LEVEL_TYPE_ID_MAP[node.SchoolLevel])
+	case data.CRITERIA_NODE_HOUSEHOLD_SIZE:
+		return fmt.Sprintf("household size %s %d", condition, node.Count)
+	case data.CRITERIA_NODE_APPLICANT_AGE:
+		return fmt.Sprintf("applicant age %s %d", condition, node.Age)
+	case data.CRITERIA_NODE_MEMBER_AGE:
+		member := "household member"
+		if node.Value != "" {
+			member = node.Value
+		}
+		return fmt.Sprintf("has %s aged %s %d", member, condition, node.Age)
+	default:
+		return node.Type
 	}
 }
 
 type Benefit struct {
-	ID     string  `json:"id"`
-	Name   string  `json:"name"`
-	Amount float64 `json:"amount"`
+	ID         string             `json:"id"`
+	Name       string             `json:"name"`
+	Amount     models.Money       `json:"amount"`
+	Recurrence *models.Recurrence `json:"recurrence,omitempty"`
+	Formula    *AmountFormula     `json:"formula,omitempty"`
+}
+
+type AmountFormula struct {
+	Rules []AmountRule `json:"rules"`
+}
+
+type AmountRule struct {
+	Member     CriteriaNode `json:"member"`
+	Amount     models.Money `json:"amount,omitempty"`
+	Tiers      []AmountTier `json:"tiers,omitempty"`
+	MaxMembers int          `json:"max_members,omitempty"`
+}
+
+type AmountTier struct {
+	Member CriteriaNode `json:"member"`
+	Amount models.Money `json:"amount"`
+}
+
+// Entitlement is the amount of one benefit an applicant is entitled to per installment,
+// the base amount plus what each household member adds through the benefit's formula
+type Entitlement struct {
+	BenefitID   string              `json:"benefit_id"`
+	BenefitName string              `json:"benefit_name"`
+	BaseAmount  models.Money        `json:"base_amount"`
+	Amount      models.Money        `json:"amount"`
+	Members     []EntitlementMember `json:"members,omitempty"`
+}
+
+// EntitlementMember is a household member counted by a formula rule (numbered from 1)
+type EntitlementMember struct {
+	Rule   int          `json:"rule"`
+	ID     string       `json:"id"`
+	Name   string       `json:"name"`
+	Amount models.Money `json:"amount"`
+}
+
+// EligibleScheme is a scheme an applicant is eligible for with the amounts they would receive
+type EligibleScheme struct {
+	Scheme
+	Entitlements []Entitlement `json:"entitlements"`
+	TotalAmount  models.Money  `json:"total_amount"` // per installment of every benefit
+}
+
+// EligibleApplicant is an applicant eligible for a scheme with the amounts they would receive
+type EligibleApplicant struct {
+	ApplicantWithHousehold
+	Entitlements []Entitlement `json:"entitlements"`
+	TotalAmount  models.Money  `json:"total_amount"`
 }
 
 type Scheme struct {
-	ID       string    `json:"id"`
-	Name     string    `json:"name"`
-	Criteria Criteria  `json:"criteria,omitempty"`
-	Benefits []Benefit `json:"benefits"`
+	ID                  string        `json:"id"`
+	Name                string        `json:"name"`
+	Version             int           `json:"version"`
+	Criteria            Criteria      `json:"criteria,omitempty"`
+	Currency            string        `json:"currency"`
+	Benefits            []Benefit     `json:"benefits"`
+	EffectiveFrom       string        `json:"effective_from,omitempty"`
+	EffectiveTo         string        `json:"effective_to,omitempty"`
+	ApplicationDeadline string        `json:"application_deadline,omitempty"`
+	Budget              *models.Money `json:"budget,omitempty"`
+	MaxRecipients       *int          `json:"max_recipients,omitempty"`
+	ReservedBudget      models.Money  `json:"reserved_budget"`
+	ReservedRecipients  int           `json:"reserved_recipients"`
+	// Only set when the scheme has the matching cap
+	RemainingBudget *models.Money `json:"remaining_budget,omitempty"`
+	RemainingSlots  *int          `json:"remaining_slots,omitempty"`
+}
+
+// SchemeVersion is the terms of a scheme as of one version
+type SchemeVersion struct {
+	SchemeID            string        `json:"scheme_id"`
+	Version             int           `json:"version"`
+	Name                string        `json:"name"`
+	Criteria            Criteria      `json:"criteria"`
+	Currency            string        `json:"currency"`
+	Benefits            []Benefit     `json:"benefits"`
+	EffectiveFrom       string        `json:"effective_from,omitempty"`
+	EffectiveTo         string        `json:"effective_to,omitempty"`
+	ApplicationDeadline string        `json:"application_deadline,omitempty"`
+	Budget              *models.Money `json:"budget,omitempty"`
+	MaxRecipients       *int          `json:"max_recipients,omitempty"`
+	CreatedBy           string        `json:"created_by"`
+	CreatedAt           time.Time     `json:"created_at"`
 }
 
 type Criteria struct {
-	EmploymentStatus string    `json:"employment_status"`
-	HasChildren      *Children `json:"has_children,omitempty"`
+	Version int           `json:"version"`
+	Rule    *CriteriaNode `json:"rule,omitempty"`
+}
+
+type CriteriaNode struct {
+	Type        string         `json:"type"`
+	Nodes       []CriteriaNode `json:"nodes,omitempty"`
+	Value       string         `json:"value,omitempty"`
+	Condition   string         `json:"condition,omitempty"`
+	SchoolLevel string         `json:"school_level,omitempty"`
+	Count       *int           `json:"count,omitempty"`
+	Age         *int           `json:"age,omitempty"`
+}
+
+// CriterionResult is the evaluation trace of one criteria node for an applicant
+type CriterionResult struct {
+	Type           string            `json:"type"`
+	Description    string            `json:"description"`
+	Passed         bool              `json:"passed"`
+	Actual         string            `json:"actual,omitempty"`
+	MatchedMembers []MemberResult    `json:"matched_members,omitempty"`
+	MissedMembers  []MemberResult    `json:"missed_members,omitempty"`
+	Nodes          []CriterionResult `json:"nodes,omitempty"`
+}
+
+// MemberResult names a household member considered by a household criterion
+type MemberResult struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Detail string `json:"detail"`
 }
 
-type Children struct {
-	SchoolLevel          string `json:"school_level"`
-	SchoolLevelCondition string `json:"-"`
+type SchemeEligibility struct {
+	Scheme                Scheme           `json:"scheme"`
+	Eligible              bool             `json:"eligible"`
+	Open                  bool             `json:"open"`                   // within the effective period on as_of
+	AcceptingApplications bool             `json:"accepting_applications"` // open and before the application deadline on as_of
+	AsOf                  string           `json:"as_of"`
+	Trace                 *CriterionResult `json:"trace,omitempty"`
+	// Only set when the applicant is eligible
+	Entitlements []Entitlement `json:"entitlements,omitempty"`
+	TotalAmount  *models.Money `json:"total_amount,omitempty"`
 }
@@ -0,0 +1,16 @@
+package dto
+
+type WebhookSubscriptionInput struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	// Generated when empty on create, kept when empty on update
+	Secret string `json:"secret"`
+	Active *bool  `json:"active"`
+}
+
+// WebhookRun reports one pass of the webhook delivery worker
+type WebhookRun struct {
+	Delivered int `json:"delivered"`
+	Retrying  int `json:"retrying"`
+	Dead      int `json:"dead"`
+}
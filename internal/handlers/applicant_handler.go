@@ -3,6 +3,8 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
 
@@ -26,7 +28,7 @@ func (h *ApplicantHandler) CreateApplicant(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.RegisterApplicantWithHousehold(&data); err != nil {
+	if err := h.Service.RegisterApplicantWithHousehold(&data, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to register applicant")
 		return
 	}
@@ -48,13 +50,42 @@ func (h *ApplicantHandler) GetApplicant(c *gin.Context) {
 
 // RETRIEVE All Applicants
 func (h *ApplicantHandler) GetAllApplicants(c *gin.Context) {
-	applicants, err := h.Service.GetApplicants(c)
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid pagination parameters")
+		return
+	}
+
+	filter, err := parseApplicantFilter(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid filter parameters")
+		return
+	}
+
+	applicants, pagination, err := h.Service.GetApplicants(c, filter, opts)
 	if err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve applicants")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"applicants": applicants})
+	c.JSON(http.StatusOK, gin.H{"applicants": applicants, "pagination": pagination})
+}
+
+// SEARCH Applicants by Name
+func (h *ApplicantHandler) SearchApplicants(c *gin.Context) {
+	limit, err := parseLimit(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid pagination parameters")
+		return
+	}
+
+	results, err := h.Service.SearchApplicants(c.Query("q"), limit)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to search applicants")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
 }
 
 // UDPATE applicant by ID
@@ -67,7 +98,7 @@ func (h *ApplicantHandler) UpdateApplicant(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.UpdateApplicant(id, &data); err != nil {
+	if err := h.Service.UpdateApplicant(id, &data, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to update applicant")
 		return
 	}
@@ -79,7 +110,7 @@ func (h *ApplicantHandler) UpdateApplicant(c *gin.Context) {
 func (h *ApplicantHandler) DeleteApplicant(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.Service.DeleteApplicant(id); err != nil {
+	if err := h.Service.DeleteApplicant(id, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to delete applicant")
 		return
 	}
@@ -88,3 +119,40 @@ func (h *ApplicantHandler) DeleteApplicant(c *gin.Context) {
 		"message": "Applicant deleted successfully",
 	})
 }
+
+// RESTORE Deleted Applicant by ID
+func (h *ApplicantHandler) RestoreApplicant(c *gin.Context) {
+	id := c.Param("id")
+
+	applicant, err := h.Service.RestoreApplicant(id, middleware.Actor(c))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to restore applicant")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Applicant restored successfully", "applicant": applicant})
+}
+
+/* Helper Functions */
+
+func parseApplicantFilter(c *gin.Context) (dto.ApplicantFilter, error) {
+	filter := dto.ApplicantFilter{
+		EmploymentStatus: c.Query("employment_status"),
+		Sex:              c.Query("sex"),
+	}
+
+	var err error
+	if filter.BornFrom, err = parseDateQuery(c, "born_from"); err != nil {
+		return filter, err
+	}
+
+	if filter.BornTo, err = parseDateQuery(c, "born_to"); err != nil {
+		return filter, err
+	}
+
+	if filter.Created, err = parseCreatedRange(c); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
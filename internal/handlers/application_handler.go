@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
 	"github.com/gin-gonic/gin"
@@ -19,8 +24,10 @@ func NewApplicationHandler(service *services.ApplicationService) *ApplicationHan
 // CREATE Application
 func (h *ApplicationHandler) RegisterApplication(c *gin.Context) {
 	var input struct {
-		ApplicantID string `json:"applicant_id"`
-		SchemeID    string `json:"scheme_id"`
+		ApplicantID         string `json:"applicant_id"`
+		SchemeID            string `json:"scheme_id"`
+		OverrideEligibility bool   `json:"override_eligibility"`
+		OverrideReason      string `json:"override_reason"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -28,7 +35,18 @@ func (h *ApplicationHandler) RegisterApplication(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.RegisterApplication(input.ApplicantID, input.SchemeID); err != nil {
+	actor := middleware.Actor(c)
+
+	var override *dto.EligibilityOverride
+	if input.OverrideEligibility {
+		override = &dto.EligibilityOverride{Actor: actor, Reason: input.OverrideReason}
+	}
+
+	if err := h.Service.RegisterApplication(input.ApplicantID, input.SchemeID, actor, override); err != nil {
+		if respondIneligible(c, err) {
+			return
+		}
+
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to register application")
 		return
 	}
@@ -36,29 +54,34 @@ func (h *ApplicationHandler) RegisterApplication(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"message": "Application registered successfully"})
 }
 
-// RETRIEVE Application by Applicant ID or Scheme ID
+// RETRIEVE Applications, optionally filtered by Applicant ID, Scheme ID and Status
 func (h *ApplicationHandler) GetApplications(c *gin.Context) {
-	applicantID := c.Query("applicant_id")
-	schemeID := c.Query("scheme_id")
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid pagination parameters")
+		return
+	}
 
-	applications, err := h.Service.GetApplications(applicantID, schemeID)
+	created, err := parseCreatedRange(c)
 	if err != nil {
-		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve applications")
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid filter parameters")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"applications": applications})
-}
+	filter := dto.ApplicationFilter{
+		ApplicantID: c.Query("applicant_id"),
+		SchemeID:    c.Query("scheme_id"),
+		Status:      c.Query("status"),
+		Created:     created,
+	}
 
-// RETRIEVE All Applications
-func (h *ApplicationHandler) GetAllApplications(c *gin.Context) {
-	applications, err := h.Service.GetAllApplications()
+	applications, pagination, err := h.Service.GetApplications(filter, opts)
 	if err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve applications")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"applications": applications})
+	c.JSON(http.StatusOK, gin.H{"applications": applications, "pagination": pagination})
 }
 
 // UPDATE Application
@@ -71,7 +94,11 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.UpdateApplication(id, &data); err != nil {
+	if err := h.Service.UpdateApplication(id, &data, middleware.Actor(c)); err != nil {
+		if respondIneligible(c, err) {
+			return
+		}
+
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to update application")
 		return
 
@@ -84,7 +111,7 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
 	applicationID := c.Param("id")
 
-	if err := h.Service.DeleteApplication(applicationID); err != nil {
+	if err := h.Service.DeleteApplication(applicationID, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to delete application")
 		return
 	}
@@ -92,14 +119,128 @@ func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Application deleted successfully"})
 }
 
+// RESTORE Deleted Application by ID
+func (h *ApplicationHandler) RestoreApplication(c *gin.Context) {
+	id := c.Param("id")
+
+	application, err := h.Service.RestoreApplication(id, middleware.Actor(c))
+	if err != nil {
+		if respondNoCapacity(c, err) {
+			return
+		}
+
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to restore application")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Application restored successfully", "application": application})
+}
+
 // DELETE Application by Applicant ID
 func (h *ApplicationHandler) DeleteApplicationByApplicantID(c *gin.Context) {
 	applicantID := c.Param("applicant_id")
 
-	if err := h.Service.DeleteApplicationByApplicantID(applicantID); err != nil {
+	if err := h.Service.DeleteApplicationByApplicantID(applicantID, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to delete applications")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Applications deleted successfully"})
 }
+
+// UPDATE Application Status to Under Review
+func (h *ApplicationHandler) ReviewApplication(c *gin.Context) {
+	h.transitionApplication(c, data.APPLICATION_STATUS_UNDER_REVIEW)
+}
+
+// UPDATE Application Status to Approved
+func (h *ApplicationHandler) ApproveApplication(c *gin.Context) {
+	h.transitionApplication(c, data.APPLICATION_STATUS_APPROVED)
+}
+
+// UPDATE Application Status to Rejected
+func (h *ApplicationHandler) RejectApplication(c *gin.Context) {
+	h.transitionApplication(c, data.APPLICATION_STATUS_REJECTED)
+}
+
+// UPDATE Application Status to Withdrawn
+func (h *ApplicationHandler) WithdrawApplication(c *gin.Context) {
+	h.transitionApplication(c, data.APPLICATION_STATUS_WITHDRAWN)
+}
+
+// RETRIEVE Application Status History
+func (h *ApplicationHandler) GetApplicationHistory(c *gin.Context) {
+	id := c.Param("id")
+
+	history, err := h.Service.GetApplicationHistory(id)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve application history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": history})
+}
+
+// respondIneligible writes the failed criteria when err is an eligibility error,
+// or the reason when the scheme is not accepting applications
+func respondIneligible(c *gin.Context, err error) bool {
+	var closedErr *services.SchemeClosedError
+	if errors.As(err, &closedErr) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  "Scheme is not accepting applications",
+			"reason": closedErr.Reason,
+		})
+		return true
+	}
+
+	var eligibilityErr *services.EligibilityError
+	if !errors.As(err, &eligibilityErr) {
+		return false
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"error":           "Applicant is not eligible for this scheme",
+		"failed_criteria": eligibilityErr.Failures,
+	})
+	return true
+}
+
+// respondNoCapacity writes why the scheme cannot take another approved application
+func respondNoCapacity(c *gin.Context, err error) bool {
+	var capacityErr *services.CapacityError
+	if !errors.As(err, &capacityErr) {
+		return false
+	}
+
+	c.JSON(http.StatusConflict, gin.H{
+		"error":  "Scheme has no remaining capacity",
+		"reason": capacityErr.Reason,
+	})
+	return true
+}
+
+func (h *ApplicationHandler) transitionApplication(c *gin.Context, toStatus string) {
+	id := c.Param("id")
+
+	var input struct {
+		Reason string `json:"reason"`
+	}
+
+	// The body is optional when no reason is given
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid input format")
+		return
+	}
+
+	application, err := h.Service.TransitionApplication(id, toStatus, middleware.Actor(c), input.Reason)
+	if err != nil {
+		if respondNoCapacity(c, err) {
+			return
+		}
+
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to update application status")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"application": application})
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	Service *services.AuditService
+}
+
+func NewAuditHandler(service *services.AuditService) *AuditHandler {
+	return &AuditHandler{Service: service}
+}
+
+// RETRIEVE Audit Entries, optionally filtered by Actor, Entity and Action
+func (h *AuditHandler) GetAuditEntries(c *gin.Context) {
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid pagination parameters")
+		return
+	}
+
+	created, err := parseCreatedRange(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid filter parameters")
+		return
+	}
+
+	filter := dto.AuditFilter{
+		Actor:      c.Query("actor"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		Action:     c.Query("action"),
+		Created:    created,
+	}
+
+	entries, pagination, err := h.Service.GetAuditEntries(filter, opts)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve audit entries")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries, "pagination": pagination})
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type LedgerHandler struct {
+	Service *services.LedgerService
+}
+
+func NewLedgerHandler(service *services.LedgerService) *LedgerHandler {
+	return &LedgerHandler{Service: service}
+}
+
+// CREATE Disbursement of an Application's Benefit
+func (h *LedgerHandler) ScheduleDisbursement(c *gin.Context) {
+	applicationID := c.Param("id")
+
+	var input struct {
+		BenefitID   string `json:"benefit_id" binding:"required"`
+		Installment int    `json:"installment"`
+		Reference   string `json:"reference"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid input format")
+		return
+	}
+
+	disbursement, err := h.Service.ScheduleDisbursement(applicationID, input.BenefitID, input.Installment, middleware.Actor(c), input.Reference)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to schedule disbursement")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"disbursement": disbursement})
+}
+
+// RETRIEVE Disbursements of an Application
+func (h *LedgerHandler) GetApplicationDisbursements(c *gin.Context) {
+	disbursements, err := h.Service.GetApplicationDisbursements(c.Param("id"))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve disbursements")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"disbursements": disbursements})
+}
+
+// RETRIEVE Disbursement by ID
+func (h *LedgerHandler) GetDisbursement(c *gin.Context) {
+	disbursement, err := h.Service.GetDisbursement(c.Param("id"))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Disbursement not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"disbursement": disbursement})
+}
+
+func (h *LedgerHandler) PayDisbursement(c *gin.Context) {
+	h.recordDisbursement(c, data.LEDGER_ENTRY_PAID)
+}
+
+func (h *LedgerHandler) FailDisbursement(c *gin.Context) {
+	h.recordDisbursement(c, data.LEDGER_ENTRY_FAILED)
+}
+
+func (h *LedgerHandler) ReverseDisbursement(c *gin.Context) {
+	h.recordDisbursement(c, data.LEDGER_ENTRY_REVERSED)
+}
+
+// GENERATE Disbursements of the Installments due today
+func (h *LedgerHandler) GenerateDuePayouts(c *gin.Context) {
+	run, err := h.Service.GenerateDuePayouts(time.Now(), middleware.Actor(c))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to schedule due installments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Due installments scheduled successfully", "payouts": run})
+}
+
+// RETRIEVE Upcoming Installments of an Applicant
+func (h *LedgerHandler) GetApplicantInstallments(c *gin.Context) {
+	value, err := parseDateQuery(c, "until")
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid until date")
+		return
+	}
+
+	until := time.Now().AddDate(0, 0, data.INSTALLMENT_PREVIEW_DAYS)
+	if value != "" {
+		until, _ = time.Parse(utils.DateLayout, value)
+	}
+
+	installments, err := h.Service.GetApplicantInstallments(c.Param("id"), until)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve installments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"installments": installments})
+}
+
+// RETRIEVE Disbursement Balance of an Applicant
+func (h *LedgerHandler) GetApplicantBalance(c *gin.Context) {
+	balances, err := h.Service.GetApplicantBalance(c.Param("id"))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve balance")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"balances": balances})
+}
+
+// RETRIEVE Disbursement Balance of a Scheme
+func (h *LedgerHandler) GetSchemeBalance(c *gin.Context) {
+	balances, err := h.Service.GetSchemeBalance(c.Param("id"))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve balance")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"balances": balances})
+}
+
+func (h *LedgerHandler) recordDisbursement(c *gin.Context, entryType string) {
+	var input struct {
+		Reference string `json:"reference"`
+		Reason    string `json:"reason"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid input format")
+		return
+	}
+
+	disbursement, err := h.Service.RecordDisbursement(c.Param("id"), entryType, middleware.Actor(c), input.Reference, input.Reason)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to update disbursement")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"disbursement": disbursement})
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// keeps the offset of the last page well within an int
+	maxPage = 1000000
+)
+
+func parsePagination(c *gin.Context) (int, int, error) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 || page > maxPage {
+		return 0, 0, fmt.Errorf("page must be between 1 and %d", maxPage)
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(defaultPageSize)))
+	if err != nil || pageSize < 1 || pageSize > maxPageSize {
+		return 0, 0, fmt.Errorf("page_size must be between 1 and %d", maxPageSize)
+	}
+
+	return page, pageSize, nil
+}
+
+func parseLimit(c *gin.Context) (int, error) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageSize)))
+	if err != nil || limit < 1 || limit > maxPageSize {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+	}
+
+	return limit, nil
+}
+
+// parseListOptions reads the limit, cursor, sort and order query parameters
+func parseListOptions(c *gin.Context) (dto.ListOptions, error) {
+	limit, err := parseLimit(c)
+	if err != nil {
+		return dto.ListOptions{}, err
+	}
+
+	order := c.DefaultQuery("order", data.SORT_ORDER_ASC)
+	if order != data.SORT_ORDER_ASC && order != data.SORT_ORDER_DESC {
+		return dto.ListOptions{}, errors.New("order must be 'asc' or 'desc'")
+	}
+
+	return dto.ListOptions{
+		Sort:   c.DefaultQuery("sort", data.SORT_CREATED_AT),
+		Desc:   order == data.SORT_ORDER_DESC,
+		Limit:  limit,
+		Cursor: c.Query("cursor"),
+	}, nil
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query parameter
+func parseDateQuery(c *gin.Context, key string) (string, error) {
+	value := c.Query(key)
+	if value == "" {
+		return "", nil
+	}
+
+	if _, err := time.Parse(utils.DateLayout, value); err != nil {
+		return "", fmt.Errorf("%s must be a date in YYYY-MM-DD format", key)
+	}
+	return value, nil
+}
+
+// parseAsOf reads the optional as_of reference date, defaulting to now
+func parseAsOf(c *gin.Context) (time.Time, error) {
+	value, err := parseDateQuery(c, "as_of")
+	if err != nil || value == "" {
+		return time.Now(), err
+	}
+
+	asOf, _ := time.Parse(utils.DateLayout, value)
+	return asOf, nil
+}
+
+// parseCreatedRange reads the created_from and created_to dates, both inclusive
+func parseCreatedRange(c *gin.Context) (dto.CreatedRange, error) {
+	var created dto.CreatedRange
+
+	from, err := parseDateQuery(c, "created_from")
+	if err != nil {
+		return created, err
+	}
+	if from != "" {
+		start, _ := time.Parse(utils.DateLayout, from)
+		created.From = &start
+	}
+
+	to, err := parseDateQuery(c, "created_to")
+	if err != nil {
+		return created, err
+	}
+	if to != "" {
+		end, _ := time.Parse(utils.DateLayout, to)
+		end = end.AddDate(0, 0, 1)
+		created.Before = &end
+	}
+
+	return created, nil
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PurgeHandler struct {
+	Service *services.PurgeService
+}
+
+func NewPurgeHandler(service *services.PurgeService) *PurgeHandler {
+	return &PurgeHandler{Service: service}
+}
+
+// PURGE Records deleted before the Retention Period
+func (h *PurgeHandler) PurgeDeleted(c *gin.Context) {
+	result, err := h.Service.PurgeDeleted(middleware.Actor(c))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to purge deleted records")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Deleted records purged successfully", "purged": result})
+}
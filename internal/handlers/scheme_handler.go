@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
 
@@ -25,7 +29,7 @@ func (h *SchemeHandler) CreateScheme(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.CreateScheme(&data); err != nil {
+	if err := h.Service.CreateScheme(&data, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to create scheme")
 		return
 	}
@@ -35,12 +39,30 @@ func (h *SchemeHandler) CreateScheme(c *gin.Context) {
 
 // RETRIEVE All Scheme
 func (h *SchemeHandler) GetAllSchemes(c *gin.Context) {
-	schemes, err := h.Service.GetAllSchemes()
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid pagination parameters")
+		return
+	}
+
+	created, err := parseCreatedRange(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid filter parameters")
+		return
+	}
+
+	filter := dto.SchemeFilter{
+		Name:    c.Query("name"),
+		Created: created,
+	}
+
+	schemes, pagination, err := h.Service.GetAllSchemes(filter, opts)
 	if err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve schemes")
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"schemes": schemes})
+	c.JSON(http.StatusOK, gin.H{"schemes": schemes, "pagination": pagination})
 }
 
 // RETRIEVE Scheme by ID
@@ -55,6 +77,36 @@ func (h *SchemeHandler) GetSchemeByID(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"scheme": scheme})
 }
 
+// RETRIEVE Versions of a Scheme
+func (h *SchemeHandler) GetSchemeVersions(c *gin.Context) {
+	id := c.Param("id")
+	versions, err := h.Service.GetSchemeVersions(id)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve scheme versions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+// RETRIEVE Scheme Version
+func (h *SchemeHandler) GetSchemeVersion(c *gin.Context) {
+	id := c.Param("id")
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.Error(errors.New("version must be a positive integer")).SetType(gin.ErrorTypePublic).SetMeta("Invalid scheme version")
+		return
+	}
+
+	schemeVersion, err := h.Service.GetSchemeVersion(id, version)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Scheme version not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"version": schemeVersion})
+}
+
 // UPDATE Scheme by ID
 func (h *SchemeHandler) UpdateScheme(c *gin.Context) {
 	id := c.Param("id")
@@ -65,7 +117,7 @@ func (h *SchemeHandler) UpdateScheme(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.UpdateScheme(id, &updatedData); err != nil {
+	if err := h.Service.UpdateScheme(id, &updatedData, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to update scheme")
 		return
 	}
@@ -77,7 +129,7 @@ func (h *SchemeHandler) UpdateScheme(c *gin.Context) {
 func (h *SchemeHandler) DeleteScheme(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.Service.DeleteScheme(id); err != nil {
+	if err := h.Service.DeleteScheme(id, middleware.Actor(c)); err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to delete scheme")
 		return
 	}
@@ -85,11 +137,30 @@ func (h *SchemeHandler) DeleteScheme(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Scheme deleted successfully"})
 }
 
+// RESTORE Deleted Scheme by ID
+func (h *SchemeHandler) RestoreScheme(c *gin.Context) {
+	id := c.Param("id")
+
+	scheme, err := h.Service.RestoreScheme(id, middleware.Actor(c))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to restore scheme")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Scheme restored successfully", "scheme": scheme})
+}
+
 // RETRIEVE Eligible Schemes
 func (h *SchemeHandler) GetEligibleSchemes(c *gin.Context) {
 	applicantID := c.Param("applicantID")
 
-	eligibleSchemes, err := h.Service.GetEligibleSchemes(applicantID)
+	asOf, err := parseAsOf(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid as_of date")
+		return
+	}
+
+	eligibleSchemes, err := h.Service.GetEligibleSchemes(applicantID, asOf)
 	if err != nil {
 		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to get eligible scheme")
 		return
@@ -97,3 +168,43 @@ func (h *SchemeHandler) GetEligibleSchemes(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"eligible_schemes": eligibleSchemes})
 }
+
+// RETRIEVE Eligibility Explanation for every Scheme
+func (h *SchemeHandler) ExplainEligibility(c *gin.Context) {
+	applicantID := c.Param("applicantID")
+
+	asOf, err := parseAsOf(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid as_of date")
+		return
+	}
+
+	results, err := h.Service.ExplainEligibility(applicantID, asOf)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to explain eligibility")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"schemes": results})
+}
+
+// RETRIEVE Applicants Eligible for a Scheme
+func (h *SchemeHandler) GetEligibleApplicants(c *gin.Context) {
+	id := c.Param("id")
+
+	page, pageSize, err := parsePagination(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid pagination parameters")
+		return
+	}
+
+	excludeApplied := c.Query("exclude_applied") == "true"
+
+	applicants, pagination, err := h.Service.GetEligibleApplicants(id, page, pageSize, excludeApplied)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to get eligible applicants")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"applicants": applicants, "pagination": pagination})
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	Service *services.WebhookService
+}
+
+func NewWebhookHandler(service *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{Service: service}
+}
+
+// CREATE Webhook Subscription
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var input dto.WebhookSubscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid input format")
+		return
+	}
+
+	webhook, secret, err := h.Service.CreateSubscription(input, middleware.Actor(c))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Webhook created successfully", "webhook": webhook, "secret": secret})
+}
+
+// RETRIEVE All Webhook Subscriptions
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.Service.GetSubscriptions()
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve webhooks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+// RETRIEVE Webhook Subscription by ID
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhook, err := h.Service.GetSubscription(c.Param("id"))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Webhook not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhook": webhook})
+}
+
+// UDPATE Webhook Subscription
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var input dto.WebhookSubscriptionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid input format")
+		return
+	}
+
+	webhook, err := h.Service.UpdateSubscription(c.Param("id"), input, middleware.Actor(c))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook updated successfully", "webhook": webhook})
+}
+
+// DELETE Webhook Subscription
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.Service.DeleteSubscription(c.Param("id"), middleware.Actor(c)); err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// RETRIEVE Webhook Deliveries, optionally filtered by Webhook, Status and Event Type
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid pagination parameters")
+		return
+	}
+
+	created, err := parseCreatedRange(c)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Invalid filter parameters")
+		return
+	}
+
+	filter := dto.WebhookDeliveryFilter{
+		SubscriptionID: c.Query("webhook_id"),
+		Status:         c.Query("status"),
+		EventType:      c.Query("event_type"),
+		Created:        created,
+	}
+
+	deliveries, pagination, err := h.Service.GetDeliveries(filter, opts)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to retrieve deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries, "pagination": pagination})
+}
+
+// RETRIEVE Webhook Delivery by ID
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	delivery, err := h.Service.GetDelivery(c.Param("id"))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Delivery not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"delivery": delivery})
+}
+
+// UDPATE Webhook Delivery to be sent again
+func (h *WebhookHandler) RedeliverDelivery(c *gin.Context) {
+	delivery, err := h.Service.RedeliverDelivery(c.Param("id"), middleware.Actor(c))
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to redeliver")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Delivery queued successfully", "delivery": delivery})
+}
+
+// GENERATE Attempts of the due Webhook Deliveries now
+func (h *WebhookHandler) DeliverDue(c *gin.Context) {
+	run, err := h.Service.DeliverDue(c.Request.Context())
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypePublic).SetMeta("Failed to deliver webhooks")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Due deliveries attempted successfully", "run": run})
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// responseRecorder keeps a copy of the response body while writing it
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes POST requests with an Idempotency-Key header safe to retry. The first request with a key
+// runs and its response is stored; a retry with the same key and request gets the stored response, a request
+// reusing the key for a different method, path or body is rejected. Keys belong to the token's subject,
+// so it must run after Authenticate. Responses with a 5xx status are not stored.
+func Idempotency(service *services.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(data.IDEMPOTENCY_KEY_HEADER)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > data.IDEMPOTENCY_MAX_KEY_LENGTH {
+			abortIdempotency(c, http.StatusBadRequest, "Invalid Idempotency-Key",
+				"key must be at most "+strconv.Itoa(data.IDEMPOTENCY_MAX_KEY_LENGTH)+" characters")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortIdempotency(c, http.StatusBadRequest, "Failed to read request body", err.Error())
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		actor := Actor(c)
+		record, err := service.Begin(actor, key, requestHash(c.Request, body))
+		if errors.Is(err, services.ErrIdempotencyKeyReused) {
+			abortIdempotency(c, http.StatusUnprocessableEntity, "Idempotency-Key already used for a different request", err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrIdempotencyKeyInProgress) {
+			abortIdempotency(c, http.StatusConflict, "Request with this Idempotency-Key is in progress", err.Error())
+			return
+		}
+		if err != nil {
+			abortIdempotency(c, http.StatusInternalServerError, "Failed to check Idempotency-Key", err.Error())
+			return
+		}
+
+		// Replay the response of the completed request
+		if record != nil {
+			c.Header(data.IDEMPOTENCY_REPLAYED_HEADER, "true")
+			c.Data(record.StatusCode, record.ContentType, []byte(record.Body))
+			c.Abort()
+			return
+		}
+
+		// A panicking handler releases the key before the panic is recovered
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				service.Release(actor, key)
+				panic(recovered)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Write the handler's error here, so it is stored with the other responses
+		if len(c.Errors) > 0 && !c.Writer.Written() {
+			writeError(c)
+		}
+
+		status := c.Writer.Status()
+		if status >= http.StatusInternalServerError {
+			err = service.Release(actor, key)
+		} else {
+			err = service.Complete(actor, key, status, c.Writer.Header().Get("Content-Type"), recorder.body.String())
+		}
+		if err != nil {
+			log.Printf("[ERROR] Failed to store the response of Idempotency-Key %q: %v", key, err)
+		}
+	}
+}
+
+func abortIdempotency(c *gin.Context, status int, message, details string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error":   message,
+		"details": details,
+	})
+}
+
+// requestHash identifies a request by its method, path with query and body
+func requestHash(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + " " + request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
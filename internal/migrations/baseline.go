@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// baselineColumn is a column of the baseline schema that tables created by AutoMigrate may lack
+type baselineColumn struct {
+	Table      string
+	Name       string
+	Definition map[string]string // per dialect
+}
+
+// baselineColumns were added to existing tables by AutoMigrate before the migrations were introduced
+var baselineColumns = []baselineColumn{
+	{"applications", "status", map[string]string{"postgres": "text NOT NULL DEFAULT 'submitted'", "sqlite": "text NOT NULL DEFAULT 'submitted'"}},
+	{"applications", "eligibility_overridden", map[string]string{"postgres": "boolean NOT NULL DEFAULT false", "sqlite": "numeric NOT NULL DEFAULT 0"}},
+	{"applications", "overridden_by", map[string]string{"postgres": "text", "sqlite": "text"}},
+	{"applications", "override_reason", map[string]string{"postgres": "text", "sqlite": "text"}},
+	{"applications", "overridden_at", map[string]string{"postgres": "timestamptz", "sqlite": "datetime"}},
+}
+
+// adoptBaseline brings tables that existed before the baseline, and so were left untouched by its SQL,
+// up to the baseline schema by adding the columns they lack and the indexes on them
+func adoptBaseline(tx *gorm.DB) error {
+	dialect, err := dialectOf(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range baselineColumns {
+		if tx.Migrator().HasColumn(column.Table, column.Name) {
+			continue
+		}
+
+		if err := tx.Exec("ALTER TABLE " + column.Table + " ADD COLUMN " + column.Name + " " + column.Definition[dialect]).Error; err != nil {
+			return err
+		}
+
+		log.Printf("Added column %s.%s", column.Table, column.Name)
+	}
+
+	return tx.Exec("CREATE INDEX IF NOT EXISTS idx_applications_status ON applications (status)").Error
+}
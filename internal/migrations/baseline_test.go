@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// preSeriesSchema is the schema AutoMigrate created before the application status and eligibility overrides
+const preSeriesSchema = `
+CREATE TABLE applicants (id text PRIMARY KEY, name text, employment_status text, sex text, date_of_birth text, created_at datetime, updated_at datetime);
+CREATE TABLE household_members (id text PRIMARY KEY, name text, employment_status text, sex text, date_of_birth text, relation text, applicant_id text NOT NULL, school_level int);
+CREATE TABLE schemes (id text PRIMARY KEY, name text, criteria text, created_at datetime, updated_at datetime);
+CREATE TABLE benefits (id text PRIMARY KEY, name text, amount real, scheme_id text NOT NULL);
+CREATE TABLE applications (id text PRIMARY KEY, applicant_id text NOT NULL, scheme_id text NOT NULL, created_at datetime, updated_at datetime);
+CREATE INDEX idx_applications_applicant_id ON applications (applicant_id);
+CREATE INDEX idx_applications_scheme_id ON applications (scheme_id);
+
+INSERT INTO applicants VALUES ('11111111-1111-1111-1111-111111111111', 'Mary', 'unemployed', 'female', '1984-10-06', '2024-01-01 00:00:00', '2024-01-01 00:00:00');
+INSERT INTO schemes VALUES ('22222222-2222-2222-2222-222222222222', 'Retrenchment', '{"employment_status":"unemployed"}', '2024-01-01 00:00:00', '2024-01-01 00:00:00');
+INSERT INTO benefits VALUES ('33333333-3333-3333-3333-333333333333', 'SkillsFuture Credits', 500, '22222222-2222-2222-2222-222222222222');
+INSERT INTO applications VALUES ('44444444-4444-4444-4444-444444444444', '11111111-1111-1111-1111-111111111111', '22222222-2222-2222-2222-222222222222', '2024-01-01 00:00:00', '2024-01-01 00:00:00');
+`
+
+func TestUpAdoptsPreSeriesSchema(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "fas.db")+"?_pragma=foreign_keys(1)"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(preSeriesSchema).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Up(db); err != nil {
+		t.Fatalf("up on the pre-series schema: %v", err)
+	}
+
+	pending, err := Pending(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) > 0 {
+		t.Fatalf("%d migrations still pending", len(pending))
+	}
+
+	var application struct {
+		Status                string
+		EligibilityOverridden bool
+	}
+	if err := db.Table("applications").Select("status", "eligibility_overridden").Take(&application).Error; err != nil {
+		t.Fatal(err)
+	}
+	if application.Status != "submitted" || application.EligibilityOverridden {
+		t.Errorf("existing application got status %q and override %v, want submitted and false", application.Status, application.EligibilityOverridden)
+	}
+
+	var criteria string
+	if err := db.Table("schemes").Select("criteria").Row().Scan(&criteria); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"version":2,"rule":{"type":"and","nodes":[{"type":"employment_status","value":"unemployed"}]}}`; criteria != want {
+		t.Errorf("scheme criteria are %s, want %s", criteria, want)
+	}
+
+	var amount int64
+	if err := db.Table("benefits").Select("amount").Row().Scan(&amount); err != nil {
+		t.Fatal(err)
+	}
+	if amount != 50000 {
+		t.Errorf("benefit amount is %d minor units, want 50000", amount)
+	}
+}
@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"encoding/json"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// The criteria formats are copied here as they were when this migration was released,
+// so later changes to models.Criteria cannot change what it writes
+
+// flatCriteria is the version 1 format, or the version 2 tree when Rule or Version is set
+type flatCriteria struct {
+	Version          int             `json:"version"`
+	Rule             json.RawMessage `json:"rule"`
+	EmploymentStatus string          `json:"employment_status"`
+	HasChildren      *struct {
+		SchoolLevel          int `json:"school_level"`
+		SchoolLevelCondition int `json:"school_level_condition"`
+	} `json:"has_children"`
+}
+
+type treeCriteriaNode struct {
+	Type        string             `json:"type"`
+	Nodes       []treeCriteriaNode `json:"nodes,omitempty"`
+	Value       string             `json:"value,omitempty"`
+	Condition   int                `json:"condition,omitempty"`
+	SchoolLevel int                `json:"school_level,omitempty"`
+}
+
+// treeCriteria is the version 2 format
+type treeCriteria struct {
+	Version int               `json:"version"`
+	Rule    *treeCriteriaNode `json:"rule,omitempty"`
+}
+
+const treeCriteriaVersion = 2
+
+// upgradeSchemeCriteria rewrites criteria stored in the version 1 format as the equivalent version 2 "and" group.
+// It reads the table and the JSON directly so later changes to the models cannot break it.
+func upgradeSchemeCriteria(tx *gorm.DB) error {
+	var schemes []struct {
+		ID       string
+		Criteria *string
+	}
+	if err := tx.Table("schemes").Select("id", "criteria").Find(&schemes).Error; err != nil {
+		return err
+	}
+
+	for _, scheme := range schemes {
+		if scheme.Criteria == nil {
+			continue
+		}
+
+		var flat flatCriteria
+		if err := json.Unmarshal([]byte(*scheme.Criteria), &flat); err != nil {
+			return err
+		}
+
+		if flat.Version >= treeCriteriaVersion || (len(flat.Rule) > 0 && string(flat.Rule) != "null") {
+			continue
+		}
+
+		var nodes []treeCriteriaNode
+		if flat.EmploymentStatus != "" {
+			nodes = append(nodes, treeCriteriaNode{Type: "employment_status", Value: flat.EmploymentStatus})
+		}
+		if flat.HasChildren != nil && flat.HasChildren.SchoolLevel != 0 {
+			nodes = append(nodes, treeCriteriaNode{
+				Type:        "has_children",
+				Condition:   flat.HasChildren.SchoolLevelCondition,
+				SchoolLevel: flat.HasChildren.SchoolLevel,
+			})
+		}
+
+		upgraded := treeCriteria{Version: treeCriteriaVersion}
+		if len(nodes) > 0 {
+			upgraded.Rule = &treeCriteriaNode{Type: "and", Nodes: nodes}
+		}
+
+		criteria, err := json.Marshal(upgraded)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec("UPDATE schemes SET criteria = ? WHERE id = ?", string(criteria), scheme.ID).Error; err != nil {
+			return err
+		}
+
+		log.Printf("Upgraded criteria for scheme %s to version %d", scheme.ID, treeCriteriaVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// Dialects are the database drivers that ship their own set of migration files
+var Dialects = []string{"postgres", "sqlite"}
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// goMigrations holds data migrations that cannot be expressed in SQL, run after the SQL of the same version
+var goMigrations = map[int]func(tx *gorm.DB) error{
+	1: adoptBaseline,
+	2: upgradeSchemeCriteria,
+	7: backfillSchemeVersions,
+}
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is a migration with the time it was applied, nil when it is still pending
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// schemaMigration is a row of the schema_migrations table
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+/* Helper Functions */
+
+func parseFileName(name string) (version int, title, direction string, ok bool) {
+	match := fileNamePattern.FindStringSubmatch(name)
+	if match == nil {
+		return 0, "", "", false
+	}
+
+	version, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, "", "", false
+	}
+
+	return version, match[2], match[3], true
+}
+
+// isBlank reports whether a migration file contains nothing but comments
+func isBlank(sql string) bool {
+	for _, line := range strings.Split(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return false
+		}
+	}
+	return true
+}
+
+func dialectOf(db *gorm.DB) (string, error) {
+	name := db.Dialector.Name()
+	for _, dialect := range Dialects {
+		if dialect == name {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no migrations for database driver '%s'", name)
+}
+
+func ensureTable(db *gorm.DB) error {
+	return db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    integer PRIMARY KEY,
+    name       text NOT NULL,
+    applied_at timestamp NOT NULL
+)`).Error
+}
+
+func applied(db *gorm.DB) (map[int]schemaMigration, error) {
+	rows := make(map[int]schemaMigration)
+	if !db.Migrator().HasTable(schemaMigration{}) {
+		return rows, nil
+	}
+
+	var records []schemaMigration
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		rows[record.Version] = record
+	}
+	return rows, nil
+}
+
+/* Migration Functions */
+
+// Load returns the embedded migrations of a dialect ordered by version
+func Load(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for database driver '%s'", dialect)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		version, name, direction, ok := parseFileName(entry.Name())
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name '%s'", entry.Name())
+		}
+
+		content, err := fs.ReadFile(files, dialect+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migration %04d has more than one name", version)
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Status lists every known migration and when it was applied
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	dialect, err := dialectOf(db)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := Load(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	done, err := applied(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, migration := range migrations {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if record, ok := done[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+
+	return statuses, nil
+}
+
+// Pending returns the migrations that have not been applied yet
+func Pending(db *gorm.DB) ([]Migration, error) {
+	statuses, err := Status(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration in order, each in its own transaction
+func Up(db *gorm.DB) ([]Migration, error) {
+	if err := ensureTable(db); err != nil {
+		return nil, err
+	}
+
+	pending, err := Pending(db)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range pending {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if !isBlank(migration.Up) {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+			}
+
+			if fn, ok := goMigrations[migration.Version]; ok {
+				if err := fn(tx); err != nil {
+					return err
+				}
+			}
+
+			// The primary key stops a concurrent run from applying the same version twice
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return pending[:i], fmt.Errorf("migration %04d_%s failed: %v", migration.Version, migration.Name, err)
+		}
+	}
+
+	return pending, nil
+}
+
+// Down rolls back the last applied migrations, most recent first
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	statuses, err := Status(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollback []Migration
+	for i := len(statuses) - 1; i >= 0 && len(rollback) < steps; i-- {
+		if statuses[i].AppliedAt != nil {
+			rollback = append(rollback, statuses[i].Migration)
+		}
+	}
+
+	for i, migration := range rollback {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if !isBlank(migration.Down) {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+			}
+
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return rollback[:i], fmt.Errorf("rollback of %04d_%s failed: %v", migration.Version, migration.Name, err)
+		}
+	}
+
+	return rollback, nil
+}
+
+// Create writes empty up and down files for the next version in every dialect directory under dir
+func Create(dir, name string) ([]string, error) {
+	name = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("migration name is required")
+	}
+
+	next := 1
+	for _, dialect := range Dialects {
+		entries, err := os.ReadDir(filepath.Join(dir, dialect))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if version, _, _, ok := parseFileName(entry.Name()); ok && version >= next {
+				next = version + 1
+			}
+		}
+	}
+
+	var created []string
+	for _, dialect := range Dialects {
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(dir, dialect, fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+			content := fmt.Sprintf("-- %04d_%s (%s, %s)\n", next, name, dialect, direction)
+
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return created, err
+			}
+			created = append(created, path)
+		}
+	}
+
+	return created, nil
+}
@@ -0,0 +1,2 @@
+-- Criteria stored in the version 1 flat format are rewritten as expression
+-- trees by upgradeSchemeCriteria (criteria.go) after this file is applied.
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_household_members_name_trgm;
+DROP INDEX IF EXISTS idx_applicants_name_trgm;
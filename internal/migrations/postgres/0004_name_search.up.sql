@@ -0,0 +1,6 @@
+-- Trigram indexes for fuzzy applicant and household member name search
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_applicants_name_trgm ON applicants USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_household_members_name_trgm ON household_members USING gin (name gin_trgm_ops);
@@ -0,0 +1,28 @@
+-- Append-only audit log of every change made through the API
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id           uuid PRIMARY KEY,
+    actor        text NOT NULL,
+    entity_type  text NOT NULL,
+    entity_id    uuid NOT NULL,
+    action       text NOT NULL,
+    before_state jsonb,
+    after_state  jsonb,
+    created_at   timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, created_at);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update_delete BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,9 @@
+-- Soft deletes: deleted rows keep their data and are hidden by deleted_at until they are restored or purged
+
+ALTER TABLE applicants ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_applicants_deleted_at ON applicants (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_schemes_deleted_at ON schemes (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_applications_deleted_at ON applications (deleted_at);
@@ -0,0 +1,5 @@
+ALTER TABLE applications DROP COLUMN IF EXISTS scheme_version;
+ALTER TABLE schemes DROP COLUMN IF EXISTS version;
+
+DROP TABLE IF EXISTS scheme_versions;
+DROP FUNCTION IF EXISTS scheme_versions_immutable();
@@ -0,0 +1,28 @@
+-- Immutable scheme versions. Applications record the version they were submitted against.
+-- Existing schemes get a version 1 snapshot of their current terms, which existing applications are pinned to.
+
+CREATE TABLE IF NOT EXISTS scheme_versions (
+    id         uuid PRIMARY KEY,
+    scheme_id  uuid NOT NULL,
+    version    integer NOT NULL,
+    name       text,
+    criteria   jsonb,
+    benefits   jsonb,
+    created_by text,
+    created_at timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scheme_versions_scheme_version ON scheme_versions (scheme_id, version);
+
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS scheme_version integer NOT NULL DEFAULT 1;
+
+-- Versions are removed only when their scheme is purged
+CREATE OR REPLACE FUNCTION scheme_versions_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'scheme versions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER scheme_versions_no_update BEFORE UPDATE ON scheme_versions
+    FOR EACH ROW EXECUTE FUNCTION scheme_versions_immutable();
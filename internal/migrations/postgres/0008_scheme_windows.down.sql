@@ -0,0 +1,7 @@
+ALTER TABLE scheme_versions DROP COLUMN IF EXISTS application_deadline;
+ALTER TABLE scheme_versions DROP COLUMN IF EXISTS effective_to;
+ALTER TABLE scheme_versions DROP COLUMN IF EXISTS effective_from;
+
+ALTER TABLE schemes DROP COLUMN IF EXISTS application_deadline;
+ALTER TABLE schemes DROP COLUMN IF EXISTS effective_to;
+ALTER TABLE schemes DROP COLUMN IF EXISTS effective_from;
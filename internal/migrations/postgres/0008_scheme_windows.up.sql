@@ -0,0 +1,10 @@
+-- Effective period and application deadline of a scheme, as inclusive YYYY-MM-DD dates.
+-- Empty means no limit, so existing schemes stay open.
+
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS effective_from text NOT NULL DEFAULT '';
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS effective_to text NOT NULL DEFAULT '';
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS application_deadline text NOT NULL DEFAULT '';
+
+ALTER TABLE scheme_versions ADD COLUMN IF NOT EXISTS effective_from text NOT NULL DEFAULT '';
+ALTER TABLE scheme_versions ADD COLUMN IF NOT EXISTS effective_to text NOT NULL DEFAULT '';
+ALTER TABLE scheme_versions ADD COLUMN IF NOT EXISTS application_deadline text NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+ALTER TABLE applications DROP COLUMN IF EXISTS reserved_amount;
+
+ALTER TABLE scheme_versions DROP COLUMN IF EXISTS max_recipients;
+ALTER TABLE scheme_versions DROP COLUMN IF EXISTS budget;
+
+ALTER TABLE schemes DROP COLUMN IF EXISTS reserved_recipients;
+ALTER TABLE schemes DROP COLUMN IF EXISTS reserved_budget;
+ALTER TABLE schemes DROP COLUMN IF EXISTS max_recipients;
+ALTER TABLE schemes DROP COLUMN IF EXISTS budget;
@@ -0,0 +1,26 @@
+-- Optional budget and recipient caps of a scheme, and the capacity reserved by approved applications.
+
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS budget decimal;
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS max_recipients integer;
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS reserved_budget decimal NOT NULL DEFAULT 0;
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS reserved_recipients integer NOT NULL DEFAULT 0;
+
+ALTER TABLE scheme_versions ADD COLUMN IF NOT EXISTS budget decimal;
+ALTER TABLE scheme_versions ADD COLUMN IF NOT EXISTS max_recipients integer;
+
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS reserved_amount decimal NOT NULL DEFAULT 0;
+
+-- Applications approved before caps existed hold the benefits of their scheme
+UPDATE applications
+SET reserved_amount = (SELECT COALESCE(SUM(amount), 0) FROM benefits WHERE benefits.scheme_id = applications.scheme_id)
+WHERE status = 'approved';
+
+UPDATE schemes
+SET reserved_budget = (
+        SELECT COALESCE(SUM(reserved_amount), 0) FROM applications
+        WHERE applications.scheme_id = schemes.id AND status = 'approved' AND deleted_at IS NULL
+    ),
+    reserved_recipients = (
+        SELECT COUNT(*) FROM applications
+        WHERE applications.scheme_id = schemes.id AND status = 'approved' AND deleted_at IS NULL
+    );
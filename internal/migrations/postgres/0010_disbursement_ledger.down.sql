@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS disbursement_ledger;
+DROP FUNCTION IF EXISTS disbursement_ledger_append_only();
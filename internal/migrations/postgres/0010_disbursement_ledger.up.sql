@@ -0,0 +1,35 @@
+-- Immutable ledger of benefit disbursements. Entries are only appended; corrections are reversal entries.
+
+CREATE TABLE IF NOT EXISTS disbursement_ledger (
+    id              uuid PRIMARY KEY,
+    disbursement_id uuid NOT NULL,
+    sequence        integer NOT NULL,
+    application_id  uuid NOT NULL,
+    applicant_id    uuid NOT NULL,
+    scheme_id       uuid NOT NULL,
+    benefit_id      uuid NOT NULL,
+    benefit_name    text,
+    type            text NOT NULL,
+    amount          decimal,
+    reference       text,
+    reason          text,
+    created_by      text NOT NULL,
+    created_at      timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disbursement_ledger_sequence ON disbursement_ledger (disbursement_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_disbursement_ledger_application_id ON disbursement_ledger (application_id);
+CREATE INDEX IF NOT EXISTS idx_disbursement_ledger_applicant_id ON disbursement_ledger (applicant_id);
+CREATE INDEX IF NOT EXISTS idx_disbursement_ledger_scheme_id ON disbursement_ledger (scheme_id);
+
+CREATE OR REPLACE FUNCTION disbursement_ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'disbursement_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER disbursement_ledger_no_update_delete BEFORE UPDATE OR DELETE ON disbursement_ledger
+    FOR EACH ROW EXECUTE FUNCTION disbursement_ledger_append_only();
+
+CREATE TRIGGER disbursement_ledger_no_truncate BEFORE TRUNCATE ON disbursement_ledger
+    FOR EACH STATEMENT EXECUTE FUNCTION disbursement_ledger_append_only();
@@ -0,0 +1,22 @@
+DROP TRIGGER IF EXISTS scheme_versions_no_update ON scheme_versions;
+
+UPDATE scheme_versions
+SET benefits = (
+        SELECT jsonb_agg(benefit || jsonb_build_object('amount', (benefit->>'amount')::numeric) ORDER BY position)
+        FROM jsonb_array_elements(benefits) WITH ORDINALITY AS elements(benefit, position)
+    )
+WHERE jsonb_typeof(benefits) = 'array' AND jsonb_array_length(benefits) > 0;
+
+CREATE TRIGGER scheme_versions_no_update BEFORE UPDATE ON scheme_versions
+    FOR EACH ROW EXECUTE FUNCTION scheme_versions_immutable();
+
+ALTER TABLE disbursement_ledger DROP COLUMN IF EXISTS currency;
+ALTER TABLE scheme_versions DROP COLUMN IF EXISTS currency;
+ALTER TABLE schemes DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE disbursement_ledger ALTER COLUMN amount TYPE decimal USING amount / 100.0;
+ALTER TABLE applications ALTER COLUMN reserved_amount TYPE decimal USING reserved_amount / 100.0;
+ALTER TABLE scheme_versions ALTER COLUMN budget TYPE decimal USING budget / 100.0;
+ALTER TABLE schemes ALTER COLUMN reserved_budget TYPE decimal USING reserved_budget / 100.0;
+ALTER TABLE schemes ALTER COLUMN budget TYPE decimal USING budget / 100.0;
+ALTER TABLE benefits ALTER COLUMN amount TYPE decimal USING amount / 100.0;
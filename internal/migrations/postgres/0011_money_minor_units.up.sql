@@ -0,0 +1,33 @@
+-- Amounts are stored as integer minor units (cents) instead of decimal major units,
+-- and schemes state the currency their amounts are in. Existing amounts are rounded to the cent.
+
+ALTER TABLE benefits ALTER COLUMN amount TYPE bigint USING round(amount * 100);
+ALTER TABLE schemes ALTER COLUMN budget TYPE bigint USING round(budget * 100);
+ALTER TABLE schemes
+    ALTER COLUMN reserved_budget DROP DEFAULT,
+    ALTER COLUMN reserved_budget TYPE bigint USING round(reserved_budget * 100),
+    ALTER COLUMN reserved_budget SET DEFAULT 0;
+ALTER TABLE scheme_versions ALTER COLUMN budget TYPE bigint USING round(budget * 100);
+ALTER TABLE applications
+    ALTER COLUMN reserved_amount DROP DEFAULT,
+    ALTER COLUMN reserved_amount TYPE bigint USING round(reserved_amount * 100),
+    ALTER COLUMN reserved_amount SET DEFAULT 0;
+ALTER TABLE disbursement_ledger ALTER COLUMN amount TYPE bigint USING round(amount * 100);
+
+ALTER TABLE schemes ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT 'SGD';
+ALTER TABLE scheme_versions ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT 'SGD';
+ALTER TABLE disbursement_ledger ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT 'SGD';
+
+-- Benefit amounts in version snapshots become decimal strings, the format the API now uses.
+-- The snapshots are otherwise immutable, so the trigger is lifted only for this rewrite.
+DROP TRIGGER IF EXISTS scheme_versions_no_update ON scheme_versions;
+
+UPDATE scheme_versions
+SET benefits = (
+        SELECT jsonb_agg(benefit || jsonb_build_object('amount', to_char(round((benefit->>'amount')::numeric, 2), 'FM999999999999990.00')) ORDER BY position)
+        FROM jsonb_array_elements(benefits) WITH ORDINALITY AS elements(benefit, position)
+    )
+WHERE jsonb_typeof(benefits) = 'array' AND jsonb_array_length(benefits) > 0;
+
+CREATE TRIGGER scheme_versions_no_update BEFORE UPDATE ON scheme_versions
+    FOR EACH ROW EXECUTE FUNCTION scheme_versions_immutable();
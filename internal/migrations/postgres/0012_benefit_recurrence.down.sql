@@ -0,0 +1,4 @@
+ALTER TABLE disbursement_ledger DROP COLUMN IF EXISTS due_date;
+ALTER TABLE disbursement_ledger DROP COLUMN IF EXISTS installment;
+ALTER TABLE applications DROP COLUMN IF EXISTS approved_on;
+ALTER TABLE benefits DROP COLUMN IF EXISTS recurrence;
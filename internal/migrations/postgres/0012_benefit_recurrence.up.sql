@@ -0,0 +1,28 @@
+-- Benefits can recur in installments, and disbursements record the installment they pay.
+-- Approved applications record their approval date, which installments are scheduled from.
+
+ALTER TABLE benefits ADD COLUMN IF NOT EXISTS recurrence jsonb;
+
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS approved_on text NOT NULL DEFAULT '';
+
+UPDATE applications
+SET approved_on = COALESCE(
+        (SELECT to_char(MAX(changed_at), 'YYYY-MM-DD') FROM application_status_changes
+         WHERE application_status_changes.application_id = applications.id AND to_status = 'approved'),
+        to_char(updated_at, 'YYYY-MM-DD'))
+WHERE status = 'approved';
+
+-- Existing disbursements pay the only installment of a one-off benefit, due on the day they were scheduled
+ALTER TABLE disbursement_ledger ADD COLUMN IF NOT EXISTS installment integer NOT NULL DEFAULT 1;
+ALTER TABLE disbursement_ledger ADD COLUMN IF NOT EXISTS due_date text NOT NULL DEFAULT '';
+
+-- The ledger is otherwise append-only, so its trigger is lifted only for this backfill
+ALTER TABLE disbursement_ledger DISABLE TRIGGER disbursement_ledger_no_update_delete;
+
+UPDATE disbursement_ledger
+SET due_date = (
+        SELECT to_char(MIN(created_at), 'YYYY-MM-DD') FROM disbursement_ledger AS scheduled
+        WHERE scheduled.disbursement_id = disbursement_ledger.disbursement_id
+    );
+
+ALTER TABLE disbursement_ledger ENABLE TRIGGER disbursement_ledger_no_update_delete;
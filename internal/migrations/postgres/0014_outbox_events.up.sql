@@ -0,0 +1,21 @@
+-- Transactional outbox of domain events, written with the change they describe and
+-- delivered to the event sinks by the dispatcher
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              uuid PRIMARY KEY,
+    type            text NOT NULL,
+    entity_type     text NOT NULL,
+    entity_id       uuid NOT NULL,
+    actor           text NOT NULL,
+    data            jsonb,
+    previous        jsonb,
+    created_at      timestamptz NOT NULL,
+    attempts        integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    locked_until    timestamptz,
+    last_error      text,
+    dispatched_at   timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (created_at, id) WHERE dispatched_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched_at ON outbox_events (dispatched_at) WHERE dispatched_at IS NOT NULL;
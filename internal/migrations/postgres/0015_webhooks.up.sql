@@ -0,0 +1,33 @@
+-- Webhook subscriptions and the signed deliveries of domain events to them
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id          uuid PRIMARY KEY,
+    url         text NOT NULL,
+    event_types jsonb NOT NULL,
+    secret      text NOT NULL,
+    active      boolean NOT NULL DEFAULT true,
+    created_by  text NOT NULL,
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               uuid PRIMARY KEY,
+    subscription_id  uuid NOT NULL,
+    event_id         uuid NOT NULL,
+    event_type       text NOT NULL,
+    payload          jsonb NOT NULL,
+    status           text NOT NULL,
+    attempts         integer NOT NULL DEFAULT 0,
+    last_status_code integer,
+    last_error       text,
+    next_attempt_at  timestamptz NOT NULL,
+    locked_until     timestamptz,
+    delivered_at     timestamptz,
+    created_at       timestamptz NOT NULL,
+    updated_at       timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (subscription_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (created_at, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status, created_at, id);
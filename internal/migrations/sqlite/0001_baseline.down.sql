@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS applicant_scheme_eligibilities;
+DROP TABLE IF EXISTS application_status_changes;
+DROP TABLE IF EXISTS applications;
+DROP TABLE IF EXISTS benefits;
+DROP TABLE IF EXISTS schemes;
+DROP TABLE IF EXISTS household_members;
+DROP TABLE IF EXISTS applicants;
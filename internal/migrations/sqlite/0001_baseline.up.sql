@@ -0,0 +1,83 @@
+-- Baseline schema. Tables that already exist (from the previous AutoMigrate
+-- setup) are not recreated, the columns they lack are added by the Go step of
+-- this version (adoptBaseline) so existing databases can adopt the migrations.
+
+CREATE TABLE IF NOT EXISTS applicants (
+    id                text PRIMARY KEY,
+    name              text,
+    employment_status text,
+    sex               text,
+    date_of_birth     text,
+    created_at        datetime,
+    updated_at        datetime
+);
+
+CREATE TABLE IF NOT EXISTS household_members (
+    id                text PRIMARY KEY,
+    name              text,
+    employment_status text,
+    sex               text,
+    date_of_birth     text,
+    relation          text,
+    applicant_id      text NOT NULL,
+    school_level      integer,
+    CONSTRAINT fk_applicants_household FOREIGN KEY (applicant_id) REFERENCES applicants (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_household_members_applicant_id ON household_members (applicant_id);
+
+CREATE TABLE IF NOT EXISTS schemes (
+    id         text PRIMARY KEY,
+    name       text,
+    criteria   text,
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS benefits (
+    id        text PRIMARY KEY,
+    name      text,
+    amount    real,
+    scheme_id text NOT NULL,
+    CONSTRAINT fk_schemes_benefits FOREIGN KEY (scheme_id) REFERENCES schemes (id)
+);
+
+CREATE TABLE IF NOT EXISTS applications (
+    id                     text PRIMARY KEY,
+    applicant_id           text NOT NULL,
+    scheme_id              text NOT NULL,
+    status                 text NOT NULL DEFAULT 'submitted',
+    eligibility_overridden numeric NOT NULL DEFAULT 0,
+    overridden_by          text,
+    override_reason        text,
+    overridden_at          datetime,
+    created_at             datetime,
+    updated_at             datetime
+);
+
+CREATE INDEX IF NOT EXISTS idx_applications_applicant_id ON applications (applicant_id);
+CREATE INDEX IF NOT EXISTS idx_applications_scheme_id ON applications (scheme_id);
+
+CREATE TABLE IF NOT EXISTS application_status_changes (
+    id             text PRIMARY KEY,
+    application_id text NOT NULL,
+    from_status    text,
+    to_status      text,
+    changed_by     text,
+    reason         text,
+    changed_at     datetime
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_status_changes_application_id ON application_status_changes (application_id);
+
+CREATE TABLE IF NOT EXISTS applicant_scheme_eligibilities (
+    applicant_id text,
+    scheme_id    text,
+    eligible     numeric NOT NULL,
+    evaluated_on text NOT NULL,
+    updated_at   datetime,
+    PRIMARY KEY (applicant_id, scheme_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_applicant_scheme_eligibilities_scheme_id ON applicant_scheme_eligibilities (scheme_id);
+CREATE INDEX IF NOT EXISTS idx_applicant_scheme_eligibilities_eligible ON applicant_scheme_eligibilities (eligible);
@@ -0,0 +1 @@
+-- Irreversible. Upgraded criteria remain readable, so there is nothing to undo.
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_applications_created_at;
+DROP INDEX IF EXISTS idx_schemes_created_at;
+DROP INDEX IF EXISTS idx_applicants_date_of_birth;
+DROP INDEX IF EXISTS idx_applicants_name;
+DROP INDEX IF EXISTS idx_applicants_created_at;
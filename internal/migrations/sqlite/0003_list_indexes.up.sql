@@ -0,0 +1,7 @@
+-- Indexes backing the sort orders and filters of the list endpoints
+
+CREATE INDEX IF NOT EXISTS idx_applicants_created_at ON applicants (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_applicants_name ON applicants (name, id);
+CREATE INDEX IF NOT EXISTS idx_applicants_date_of_birth ON applicants (date_of_birth, id);
+CREATE INDEX IF NOT EXISTS idx_schemes_created_at ON schemes (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_applications_created_at ON applications (created_at, id);
@@ -0,0 +1 @@
+-- SQLite has no pg_trgm, names are scored in Go when searching
@@ -0,0 +1,26 @@
+-- Append-only audit log of every change made through the API
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id           text PRIMARY KEY,
+    actor        text NOT NULL,
+    entity_type  text NOT NULL,
+    entity_id    text NOT NULL,
+    action       text NOT NULL,
+    before_state text,
+    after_state  text,
+    created_at   datetime NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor, created_at);
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
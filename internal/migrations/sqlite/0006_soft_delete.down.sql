@@ -0,0 +1,9 @@
+-- Soft deleted rows become visible again once deleted_at is dropped
+
+DROP INDEX IF EXISTS idx_applications_deleted_at;
+DROP INDEX IF EXISTS idx_schemes_deleted_at;
+DROP INDEX IF EXISTS idx_applicants_deleted_at;
+
+ALTER TABLE applications DROP COLUMN deleted_at;
+ALTER TABLE schemes DROP COLUMN deleted_at;
+ALTER TABLE applicants DROP COLUMN deleted_at;
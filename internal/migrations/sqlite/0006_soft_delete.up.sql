@@ -0,0 +1,9 @@
+-- Soft deletes: deleted rows keep their data and are hidden by deleted_at until they are restored or purged
+
+ALTER TABLE applicants ADD COLUMN deleted_at datetime;
+ALTER TABLE schemes ADD COLUMN deleted_at datetime;
+ALTER TABLE applications ADD COLUMN deleted_at datetime;
+
+CREATE INDEX IF NOT EXISTS idx_applicants_deleted_at ON applicants (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_schemes_deleted_at ON schemes (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_applications_deleted_at ON applications (deleted_at);
@@ -0,0 +1,4 @@
+ALTER TABLE applications DROP COLUMN scheme_version;
+ALTER TABLE schemes DROP COLUMN version;
+
+DROP TABLE IF EXISTS scheme_versions;
@@ -0,0 +1,24 @@
+-- Immutable scheme versions. Applications record the version they were submitted against.
+-- Existing schemes get a version 1 snapshot of their current terms, which existing applications are pinned to.
+
+CREATE TABLE IF NOT EXISTS scheme_versions (
+    id         text PRIMARY KEY,
+    scheme_id  text NOT NULL,
+    version    integer NOT NULL,
+    name       text,
+    criteria   text,
+    benefits   text,
+    created_by text,
+    created_at datetime
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scheme_versions_scheme_version ON scheme_versions (scheme_id, version);
+
+ALTER TABLE schemes ADD COLUMN version integer NOT NULL DEFAULT 1;
+ALTER TABLE applications ADD COLUMN scheme_version integer NOT NULL DEFAULT 1;
+
+-- Versions are removed only when their scheme is purged
+CREATE TRIGGER IF NOT EXISTS scheme_versions_no_update BEFORE UPDATE ON scheme_versions
+BEGIN
+    SELECT RAISE(ABORT, 'scheme versions are immutable');
+END;
@@ -0,0 +1,7 @@
+ALTER TABLE scheme_versions DROP COLUMN application_deadline;
+ALTER TABLE scheme_versions DROP COLUMN effective_to;
+ALTER TABLE scheme_versions DROP COLUMN effective_from;
+
+ALTER TABLE schemes DROP COLUMN application_deadline;
+ALTER TABLE schemes DROP COLUMN effective_to;
+ALTER TABLE schemes DROP COLUMN effective_from;
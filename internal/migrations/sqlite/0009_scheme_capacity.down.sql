@@ -0,0 +1,9 @@
+ALTER TABLE applications DROP COLUMN reserved_amount;
+
+ALTER TABLE scheme_versions DROP COLUMN max_recipients;
+ALTER TABLE scheme_versions DROP COLUMN budget;
+
+ALTER TABLE schemes DROP COLUMN reserved_recipients;
+ALTER TABLE schemes DROP COLUMN reserved_budget;
+ALTER TABLE schemes DROP COLUMN max_recipients;
+ALTER TABLE schemes DROP COLUMN budget;
@@ -0,0 +1,33 @@
+-- Immutable ledger of benefit disbursements. Entries are only appended; corrections are reversal entries.
+
+CREATE TABLE IF NOT EXISTS disbursement_ledger (
+    id              text PRIMARY KEY,
+    disbursement_id text NOT NULL,
+    sequence        integer NOT NULL,
+    application_id  text NOT NULL,
+    applicant_id    text NOT NULL,
+    scheme_id       text NOT NULL,
+    benefit_id      text NOT NULL,
+    benefit_name    text,
+    type            text NOT NULL,
+    amount          decimal,
+    reference       text,
+    reason          text,
+    created_by      text NOT NULL,
+    created_at      datetime NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_disbursement_ledger_sequence ON disbursement_ledger (disbursement_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_disbursement_ledger_application_id ON disbursement_ledger (application_id);
+CREATE INDEX IF NOT EXISTS idx_disbursement_ledger_applicant_id ON disbursement_ledger (applicant_id);
+CREATE INDEX IF NOT EXISTS idx_disbursement_ledger_scheme_id ON disbursement_ledger (scheme_id);
+
+CREATE TRIGGER IF NOT EXISTS disbursement_ledger_no_update BEFORE UPDATE ON disbursement_ledger
+BEGIN
+    SELECT RAISE(ABORT, 'disbursement_ledger is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS disbursement_ledger_no_delete BEFORE DELETE ON disbursement_ledger
+BEGIN
+    SELECT RAISE(ABORT, 'disbursement_ledger is append-only');
+END;
@@ -0,0 +1,53 @@
+DROP TRIGGER IF EXISTS disbursement_ledger_no_update;
+DROP TRIGGER IF EXISTS scheme_versions_no_update;
+
+UPDATE scheme_versions
+SET benefits = (
+        SELECT json_group_array(json(json_set(value, '$.amount', CAST(json_extract(value, '$.amount') AS REAL))))
+        FROM json_each(scheme_versions.benefits)
+    )
+WHERE json_valid(benefits) AND json_array_length(benefits) > 0;
+
+ALTER TABLE disbursement_ledger DROP COLUMN currency;
+ALTER TABLE scheme_versions DROP COLUMN currency;
+ALTER TABLE schemes DROP COLUMN currency;
+
+ALTER TABLE disbursement_ledger ADD COLUMN amount_major decimal;
+UPDATE disbursement_ledger SET amount_major = amount / 100.0;
+ALTER TABLE disbursement_ledger DROP COLUMN amount;
+ALTER TABLE disbursement_ledger RENAME COLUMN amount_major TO amount;
+
+ALTER TABLE applications ADD COLUMN reserved_amount_major decimal NOT NULL DEFAULT 0;
+UPDATE applications SET reserved_amount_major = reserved_amount / 100.0;
+ALTER TABLE applications DROP COLUMN reserved_amount;
+ALTER TABLE applications RENAME COLUMN reserved_amount_major TO reserved_amount;
+
+ALTER TABLE scheme_versions ADD COLUMN budget_major decimal;
+UPDATE scheme_versions SET budget_major = budget / 100.0;
+ALTER TABLE scheme_versions DROP COLUMN budget;
+ALTER TABLE scheme_versions RENAME COLUMN budget_major TO budget;
+
+ALTER TABLE schemes ADD COLUMN reserved_budget_major decimal NOT NULL DEFAULT 0;
+UPDATE schemes SET reserved_budget_major = reserved_budget / 100.0;
+ALTER TABLE schemes DROP COLUMN reserved_budget;
+ALTER TABLE schemes RENAME COLUMN reserved_budget_major TO reserved_budget;
+
+ALTER TABLE schemes ADD COLUMN budget_major decimal;
+UPDATE schemes SET budget_major = budget / 100.0;
+ALTER TABLE schemes DROP COLUMN budget;
+ALTER TABLE schemes RENAME COLUMN budget_major TO budget;
+
+ALTER TABLE benefits ADD COLUMN amount_major real;
+UPDATE benefits SET amount_major = amount / 100.0;
+ALTER TABLE benefits DROP COLUMN amount;
+ALTER TABLE benefits RENAME COLUMN amount_major TO amount;
+
+CREATE TRIGGER IF NOT EXISTS scheme_versions_no_update BEFORE UPDATE ON scheme_versions
+BEGIN
+    SELECT RAISE(ABORT, 'scheme versions are immutable');
+END;
+
+CREATE TRIGGER IF NOT EXISTS disbursement_ledger_no_update BEFORE UPDATE ON disbursement_ledger
+BEGIN
+    SELECT RAISE(ABORT, 'disbursement_ledger is append-only');
+END;
@@ -0,0 +1,59 @@
+-- Amounts are stored as integer minor units (cents) instead of decimal major units,
+-- and schemes state the currency their amounts are in. Existing amounts are rounded to the cent.
+-- SQLite cannot change a column's type, so each amount is copied into a new integer column.
+
+-- The append-only and immutability triggers are lifted only for this rewrite
+DROP TRIGGER IF EXISTS disbursement_ledger_no_update;
+DROP TRIGGER IF EXISTS scheme_versions_no_update;
+
+ALTER TABLE benefits ADD COLUMN amount_minor integer;
+UPDATE benefits SET amount_minor = CAST(ROUND(amount * 100) AS INTEGER);
+ALTER TABLE benefits DROP COLUMN amount;
+ALTER TABLE benefits RENAME COLUMN amount_minor TO amount;
+
+ALTER TABLE schemes ADD COLUMN budget_minor integer;
+UPDATE schemes SET budget_minor = CAST(ROUND(budget * 100) AS INTEGER);
+ALTER TABLE schemes DROP COLUMN budget;
+ALTER TABLE schemes RENAME COLUMN budget_minor TO budget;
+
+ALTER TABLE schemes ADD COLUMN reserved_budget_minor integer NOT NULL DEFAULT 0;
+UPDATE schemes SET reserved_budget_minor = CAST(ROUND(reserved_budget * 100) AS INTEGER);
+ALTER TABLE schemes DROP COLUMN reserved_budget;
+ALTER TABLE schemes RENAME COLUMN reserved_budget_minor TO reserved_budget;
+
+ALTER TABLE scheme_versions ADD COLUMN budget_minor integer;
+UPDATE scheme_versions SET budget_minor = CAST(ROUND(budget * 100) AS INTEGER);
+ALTER TABLE scheme_versions DROP COLUMN budget;
+ALTER TABLE scheme_versions RENAME COLUMN budget_minor TO budget;
+
+ALTER TABLE applications ADD COLUMN reserved_amount_minor integer NOT NULL DEFAULT 0;
+UPDATE applications SET reserved_amount_minor = CAST(ROUND(reserved_amount * 100) AS INTEGER);
+ALTER TABLE applications DROP COLUMN reserved_amount;
+ALTER TABLE applications RENAME COLUMN reserved_amount_minor TO reserved_amount;
+
+ALTER TABLE disbursement_ledger ADD COLUMN amount_minor integer;
+UPDATE disbursement_ledger SET amount_minor = CAST(ROUND(amount * 100) AS INTEGER);
+ALTER TABLE disbursement_ledger DROP COLUMN amount;
+ALTER TABLE disbursement_ledger RENAME COLUMN amount_minor TO amount;
+
+ALTER TABLE schemes ADD COLUMN currency text NOT NULL DEFAULT 'SGD';
+ALTER TABLE scheme_versions ADD COLUMN currency text NOT NULL DEFAULT 'SGD';
+ALTER TABLE disbursement_ledger ADD COLUMN currency text NOT NULL DEFAULT 'SGD';
+
+-- Benefit amounts in version snapshots become decimal strings, the format the API now uses
+UPDATE scheme_versions
+SET benefits = (
+        SELECT json_group_array(json(json_set(value, '$.amount', printf('%.2f', json_extract(value, '$.amount')))))
+        FROM json_each(scheme_versions.benefits)
+    )
+WHERE json_valid(benefits) AND json_array_length(benefits) > 0;
+
+CREATE TRIGGER IF NOT EXISTS scheme_versions_no_update BEFORE UPDATE ON scheme_versions
+BEGIN
+    SELECT RAISE(ABORT, 'scheme versions are immutable');
+END;
+
+CREATE TRIGGER IF NOT EXISTS disbursement_ledger_no_update BEFORE UPDATE ON disbursement_ledger
+BEGIN
+    SELECT RAISE(ABORT, 'disbursement_ledger is append-only');
+END;
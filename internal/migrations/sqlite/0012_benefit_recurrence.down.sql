@@ -0,0 +1,4 @@
+ALTER TABLE disbursement_ledger DROP COLUMN due_date;
+ALTER TABLE disbursement_ledger DROP COLUMN installment;
+ALTER TABLE applications DROP COLUMN approved_on;
+ALTER TABLE benefits DROP COLUMN recurrence;
@@ -0,0 +1,31 @@
+-- Benefits can recur in installments, and disbursements record the installment they pay.
+-- Approved applications record their approval date, which installments are scheduled from.
+
+ALTER TABLE benefits ADD COLUMN recurrence text;
+
+ALTER TABLE applications ADD COLUMN approved_on text NOT NULL DEFAULT '';
+
+UPDATE applications
+SET approved_on = COALESCE(
+        (SELECT substr(MAX(changed_at), 1, 10) FROM application_status_changes
+         WHERE application_status_changes.application_id = applications.id AND to_status = 'approved'),
+        substr(updated_at, 1, 10))
+WHERE status = 'approved';
+
+-- Existing disbursements pay the only installment of a one-off benefit, due on the day they were scheduled
+ALTER TABLE disbursement_ledger ADD COLUMN installment integer NOT NULL DEFAULT 1;
+ALTER TABLE disbursement_ledger ADD COLUMN due_date text NOT NULL DEFAULT '';
+
+-- The ledger is otherwise append-only, so its trigger is lifted only for this backfill
+DROP TRIGGER IF EXISTS disbursement_ledger_no_update;
+
+UPDATE disbursement_ledger
+SET due_date = (
+        SELECT substr(MIN(created_at), 1, 10) FROM disbursement_ledger AS scheduled
+        WHERE scheduled.disbursement_id = disbursement_ledger.disbursement_id
+    );
+
+CREATE TRIGGER IF NOT EXISTS disbursement_ledger_no_update BEFORE UPDATE ON disbursement_ledger
+BEGIN
+    SELECT RAISE(ABORT, 'disbursement_ledger is append-only');
+END;
@@ -0,0 +1 @@
+ALTER TABLE benefits DROP COLUMN formula;
@@ -0,0 +1,3 @@
+-- Benefits can compute their amount from the household with a formula of per-member and tiered rules.
+
+ALTER TABLE benefits ADD COLUMN formula text;
@@ -0,0 +1,33 @@
+-- Webhook subscriptions and the signed deliveries of domain events to them
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id          text PRIMARY KEY,
+    url         text NOT NULL,
+    event_types text NOT NULL,
+    secret      text NOT NULL,
+    active      numeric NOT NULL DEFAULT 1,
+    created_by  text NOT NULL,
+    created_at  datetime NOT NULL,
+    updated_at  datetime NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               text PRIMARY KEY,
+    subscription_id  text NOT NULL,
+    event_id         text NOT NULL,
+    event_type       text NOT NULL,
+    payload          text NOT NULL,
+    status           text NOT NULL,
+    attempts         integer NOT NULL DEFAULT 0,
+    last_status_code integer,
+    last_error       text,
+    next_attempt_at  datetime NOT NULL,
+    locked_until     datetime,
+    delivered_at     datetime,
+    created_at       datetime NOT NULL,
+    updated_at       datetime NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries (subscription_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (created_at, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status, created_at, id);
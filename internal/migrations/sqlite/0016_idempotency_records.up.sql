@@ -0,0 +1,16 @@
+-- Responses to POST requests sent with an Idempotency-Key header, replayed when a request is retried
+
+CREATE TABLE IF NOT EXISTS idempotency_records (
+    actor           text NOT NULL,
+    idempotency_key text NOT NULL,
+    request_hash    text NOT NULL,
+    status_code     integer,
+    content_type    text,
+    body            text,
+    completed_at    datetime,
+    locked_until    datetime,
+    created_at      datetime NOT NULL,
+    PRIMARY KEY (actor, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_records_created_at ON idempotency_records (created_at);
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+	"gorm.io/gorm"
+)
+
+// backfillActor is recorded as the creator of versions written by a migration
+const backfillActor = "migration"
+
+// snapshotBenefit is a benefit as version 1 snapshots store it, with the amount in decimal major units
+type snapshotBenefit struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Amount   float64 `json:"amount"`
+	SchemeID string  `json:"scheme_id"`
+}
+
+// backfillSchemeVersions snapshots the current terms of every scheme, deleted ones included, as version 1.
+// It reads the tables directly so later changes to the models cannot break it.
+func backfillSchemeVersions(tx *gorm.DB) error {
+	var schemes []struct {
+		ID        string
+		Name      string
+		Criteria  models.Criteria
+		CreatedAt time.Time
+	}
+	if err := tx.Table("schemes").Select("id", "name", "criteria", "created_at").Find(&schemes).Error; err != nil {
+		return err
+	}
+
+	for _, scheme := range schemes {
+		var benefits []struct {
+			ID     string
+			Name   string
+			Amount float64
+		}
+		if err := tx.Table("benefits").Select("id", "name", "amount").Where("scheme_id = ?", scheme.ID).Find(&benefits).Error; err != nil {
+			return err
+		}
+
+		benefitList := make([]snapshotBenefit, len(benefits))
+		for i, benefit := range benefits {
+			benefitList[i] = snapshotBenefit{ID: benefit.ID, Name: benefit.Name, Amount: benefit.Amount, SchemeID: scheme.ID}
+		}
+
+		benefitJSON, err := json.Marshal(benefitList)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(
+			"INSERT INTO scheme_versions (id, scheme_id, version, name, criteria, benefits, created_by, created_at) VALUES (?, ?, 1, ?, ?, ?, ?, ?)",
+			utils.GenerateUUID(), scheme.ID, scheme.Name, scheme.Criteria, string(benefitJSON), backfillActor, scheme.CreatedAt,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Recorded version 1 of scheme %s", scheme.ID)
+	}
+
+	return nil
+}
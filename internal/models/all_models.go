@@ -1,10 +0,0 @@
-package models
-
-// list of all models for AutoMigrate
-var Models = []interface{}{
-	&Applicant{},
-	&HouseholdMember{},
-	&Scheme{},
-	&Benefit{},
-	&Application{},
-}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Applicant struct {
 	ID               string            `json:"id" gorm:"type:uuid;primaryKey"`
@@ -11,6 +15,7 @@ type Applicant struct {
 	Household        []HouseholdMember `gorm:"foreignKey:ApplicantID;constraint:OnDelete:CASCADE"`
 	CreatedAt        time.Time         `json:"created_at"`
 	UpdatedAt        time.Time         `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt    `json:"-" gorm:"index"`
 }
 
 type HouseholdMember struct {
@@ -1,11 +1,40 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Application struct {
-	ID          string    `json:"id" gorm:"type:uuid;primaryKey"`
-	ApplicantID string    `json:"applicant_id" gorm:"type:uuid;not null;index"`
-	SchemeID    string    `json:"scheme_id" gorm:"type:uuid;not null;index"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string `json:"id" gorm:"type:uuid;primaryKey"`
+	ApplicantID string `json:"applicant_id" gorm:"type:uuid;not null;index"`
+	SchemeID    string `json:"scheme_id" gorm:"type:uuid;not null;index"`
+	// The SchemeVersion whose terms the application was submitted against
+	SchemeVersion int    `json:"scheme_version" gorm:"not null;default:1"`
+	Status        string `json:"status" gorm:"not null;default:submitted;index"`
+	// Set when staff registered the application despite the applicant failing the scheme criteria
+	EligibilityOverridden bool       `json:"eligibility_overridden" gorm:"not null;default:false"`
+	OverriddenBy          string     `json:"overridden_by,omitempty"`
+	OverrideReason        string     `json:"override_reason,omitempty"`
+	OverriddenAt          *time.Time `json:"overridden_at,omitempty"`
+	// Scheme budget reserved for the application when it was approved
+	ReservedAmount Money `json:"reserved_amount" gorm:"not null;default:0"`
+	// Date (YYYY-MM-DD) the application was approved, which benefit installments are scheduled from
+	ApprovedOn string    `json:"approved_on,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// Set when the application is soft deleted, directly or together with its applicant
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// ApplicationStatusChange records a single transition of an application's status
+type ApplicationStatusChange struct {
+	ID            string    `json:"id" gorm:"type:uuid;primaryKey"`
+	ApplicationID string    `json:"application_id" gorm:"type:uuid;not null;index"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	ChangedBy     string    `json:"changed_by"`
+	Reason        string    `json:"reason"`
+	ChangedAt     time.Time `json:"changed_at"`
 }
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// AuditEntry records who changed an entity and its state before and after the change.
+// Entries are only ever appended.
+type AuditEntry struct {
+	ID         string    `json:"id" gorm:"type:uuid;primaryKey"`
+	Actor      string    `json:"actor" gorm:"not null"`
+	EntityType string    `json:"entity_type" gorm:"not null"`
+	EntityID   string    `json:"entity_id" gorm:"type:uuid;not null"`
+	Action     string    `json:"action" gorm:"not null"`
+	Before     Snapshot  `json:"before" gorm:"column:before_state"`
+	After      Snapshot  `json:"after" gorm:"column:after_state"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
+
+// Snapshot is the JSON encoded state of an entity, empty when the entity did not exist
+type Snapshot []byte
+
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	if len(s) == 0 {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+func (s *Snapshot) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*s = nil
+		return nil
+	}
+	*s = append((*s)[:0], b...)
+	return nil
+}
+
+func (s *Snapshot) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+	case []byte:
+		*s = append(Snapshot(nil), v...)
+	case string:
+		*s = Snapshot(v)
+	default:
+		return errors.New("failed to scan snapshot JSON value")
+	}
+	return nil
+}
+
+// GormDBDataType stores snapshots as jsonb on Postgres and as JSON text elsewhere
+func (Snapshot) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
+func (s Snapshot) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return string(s), nil
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// ApplicantSchemeEligibility is one cell of the materialized applicant x scheme eligibility matrix
+type ApplicantSchemeEligibility struct {
+	ApplicantID string    `json:"applicant_id" gorm:"type:uuid;primaryKey"`
+	SchemeID    string    `json:"scheme_id" gorm:"type:uuid;primaryKey;index"`
+	Eligible    bool      `json:"eligible" gorm:"not null;index"`
+	EvaluatedOn string    `json:"evaluated_on" gorm:"not null"` // reference date (YYYY-MM-DD) used for age criteria
+	UpdatedAt   time.Time `json:"updated_at"`
+}
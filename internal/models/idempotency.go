@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// IdempotencyRecord keeps the response to a POST request sent with an Idempotency-Key header,
+// so a retry with the same key gets the original response instead of running the request again.
+// Keys belong to the actor that sent them.
+type IdempotencyRecord struct {
+	Actor       string `gorm:"primaryKey"`
+	Key         string `gorm:"column:idempotency_key;primaryKey"`
+	RequestHash string `gorm:"not null"` // of the method, path and body of the request
+	// Response, set once the request completed
+	StatusCode  int
+	ContentType string
+	Body        string
+	CompletedAt *time.Time
+	// A request in progress holds the key until then
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// LedgerEntry is one immutable event of a disbursement. A disbursement starts with a scheduled entry,
+// is settled by a paid or failed entry and corrected only by a reversed entry.
+type LedgerEntry struct {
+	ID             string `json:"id" gorm:"type:uuid;primaryKey"`
+	DisbursementID string `json:"disbursement_id" gorm:"type:uuid;not null;uniqueIndex:idx_disbursement_ledger_sequence"`
+	// Position of the entry in its disbursement, unique so concurrent writers cannot both append
+	Sequence      int    `json:"sequence" gorm:"not null;uniqueIndex:idx_disbursement_ledger_sequence"`
+	ApplicationID string `json:"application_id" gorm:"type:uuid;not null;index"`
+	ApplicantID   string `json:"applicant_id" gorm:"type:uuid;not null;index"`
+	SchemeID      string `json:"scheme_id" gorm:"type:uuid;not null;index"`
+	BenefitID     string `json:"benefit_id" gorm:"type:uuid;not null"`
+	BenefitName   string `json:"benefit_name"`
+	// Installment of the benefit's schedule that the disbursement pays, and its due date (YYYY-MM-DD)
+	Installment int       `json:"installment" gorm:"not null;default:1"`
+	DueDate     string    `json:"due_date"`
+	Type        string    `json:"type" gorm:"not null"`
+	Amount      Money     `json:"amount"`
+	Currency    string    `json:"currency" gorm:"not null;default:SGD"`
+	Reference   string    `json:"reference,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	CreatedBy   string    `json:"created_by" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (LedgerEntry) TableName() string {
+	return "disbursement_ledger"
+}
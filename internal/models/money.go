@@ -0,0 +1,96 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in minor units (cents) of the currency it is stated in.
+// It is written to JSON as a decimal string such as "1250.50" and read from either
+// a decimal string or a JSON number, which is parsed from its text, never through a float.
+type Money int64
+
+const (
+	moneyDecimals = 2
+	moneyScale    = 100
+	// Whole units that still fit in an int64 of minor units
+	maxMoneyWholeDigits = 16
+)
+
+// ParseMoney parses a decimal amount with at most two decimal places, e.g. "12", "12.5" or "-0.05"
+func ParseMoney(value string) (Money, error) {
+	digits, negative := strings.CutPrefix(value, "-")
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+
+	if !isDigits(whole) || (hasFraction && !isDigits(fraction)) {
+		return 0, fmt.Errorf("invalid amount %q, expected a decimal such as 1250.50", value)
+	}
+	if len(fraction) > moneyDecimals {
+		return 0, fmt.Errorf("amount %q has more than %d decimal places", value, moneyDecimals)
+	}
+	if len(strings.TrimLeft(whole, "0")) > maxMoneyWholeDigits {
+		return 0, fmt.Errorf("amount %q is too large", value)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount %q is too large", value)
+	}
+	cents, _ := strconv.ParseInt(fraction+strings.Repeat("0", moneyDecimals-len(fraction)), 10, 64)
+
+	amount := Money(units*moneyScale + cents)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, value/moneyScale, moneyDecimals, value%moneyScale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+func (m *Money) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	text := string(b)
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(b, &text); err != nil {
+			return errors.New("invalid amount")
+		}
+	}
+
+	amount, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = amount
+	return nil
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
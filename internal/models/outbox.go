@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes,
+// so an event is recorded if and only if the change is committed. The dispatcher delivers it
+// to the event sinks afterwards and retries until every sink accepted it.
+type OutboxEvent struct {
+	ID         string    `json:"id" gorm:"type:uuid;primaryKey"` // the audit entry of the change
+	Type       string    `json:"type" gorm:"not null"`           // e.g. application.status_changed
+	EntityType string    `json:"entity_type" gorm:"not null"`
+	EntityID   string    `json:"entity_id" gorm:"type:uuid;not null"`
+	Actor      string    `json:"actor" gorm:"not null"`
+	Data       Snapshot  `json:"data"`     // the entity after the change, or before it was deleted
+	Previous   Snapshot  `json:"previous"` // the entity before an update
+	CreatedAt  time.Time `json:"created_at"`
+	// Delivery state, only changed by the dispatcher
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LockedUntil   *time.Time `json:"locked_until"`
+	LastError     string     `json:"last_error"`
+	DispatchedAt  *time.Time `json:"dispatched_at"`
+}
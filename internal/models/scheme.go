@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 type Children struct {
@@ -12,35 +16,275 @@ type Children struct {
 	SchoolLevelCondition int `json:"school_level_condition"` // e.g. "==", "<=", ">="
 }
 
+// CriteriaNode is either a group (and, or, not) over child nodes or a single predicate.
+// Which of the predicate fields are used depends on Type.
+type CriteriaNode struct {
+	Type        string         `json:"type"`
+	Nodes       []CriteriaNode `json:"nodes,omitempty"`
+	Value       string         `json:"value,omitempty"`        // employment_status, sex, has_relation, household_member_age (optional relation)
+	Condition   int            `json:"condition,omitempty"`    // key of data.CRITERIA_TYPE_ID_MAP
+	SchoolLevel int            `json:"school_level,omitempty"` // has_children
+	Count       int            `json:"count,omitempty"`        // household_size
+	Age         int            `json:"age,omitempty"`          // applicant_age, household_member_age
+}
+
+// Criteria is a versioned expression tree. A nil Rule matches every applicant.
 type Criteria struct {
-	EmploymentStatus string    `json:"employment_status"`
-	HasChildren      *Children `json:"has_children,omitempty"`
+	Version int           `json:"version"`
+	Rule    *CriteriaNode `json:"rule,omitempty"`
+
+	upgraded bool
 }
 
 type Benefit struct {
-	ID       string  `json:"id" gorm:"type:uuid;primaryKey"`
-	Name     string  `json:"name"`
-	Amount   float64 `json:"amount"`
-	SchemeID string  `json:"scheme_id" gorm:"type:uuid;not null"`
+	ID     string `json:"id" gorm:"type:uuid;primaryKey"`
+	Name   string `json:"name"`
+	Amount Money  `json:"amount"` // paid per installment, the base amount when there is a formula
+	// Nil for a benefit paid once on approval
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+	// Nil for a benefit that pays Amount whatever the household
+	Formula  *AmountFormula `json:"formula,omitempty"`
+	SchemeID string         `json:"scheme_id" gorm:"type:uuid;not null"`
+}
+
+// AmountFormula adds to a benefit's base amount for the applicant's household members. Each rule
+// selects members with a criteria node over has_relation, has_children and household_member_age
+// predicates, evaluated against one member at a time.
+type AmountFormula struct {
+	Rules []AmountRule `json:"rules"`
+}
+
+// AmountRule adds Amount for every household member matching Member. With Tiers, a member adds the
+// amount of the first tier it matches instead, and is not counted when it matches none.
+// MaxMembers, when set, counts only the members that add the most.
+type AmountRule struct {
+	Member     CriteriaNode `json:"member"`
+	Amount     Money        `json:"amount,omitempty"`
+	Tiers      []AmountTier `json:"tiers,omitempty"`
+	MaxMembers int          `json:"max_members,omitempty"`
+}
+
+type AmountTier struct {
+	Member CriteriaNode `json:"member"`
+	Amount Money        `json:"amount"`
+}
+
+// Recurrence pays a benefit in installments, due every Frequency from StartDate (YYYY-MM-DD),
+// or from the approval date when it is empty. The schedule stops after Installments payments
+// or after EndDate, whichever comes first; at least one of them is set.
+type Recurrence struct {
+	Frequency    string `json:"frequency"`
+	StartDate    string `json:"start_date,omitempty"`
+	Installments int    `json:"installments,omitempty"`
+	EndDate      string `json:"end_date,omitempty"`
 }
 
 type Scheme struct {
-	ID        string    `json:"id" gorm:"type:uuid;primaryKey"`
-	Name      string    `json:"name"`
-	Criteria  Criteria  `json:"criteria" gorm:"type:jsonb"`
-	Benefits  []Benefit `json:"benefits" gorm:"foreignKey:SchemeID"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       string   `json:"id" gorm:"type:uuid;primaryKey"`
+	Name     string   `json:"name"`
+	Version  int      `json:"version" gorm:"not null;default:1"` // current SchemeVersion
+	Criteria Criteria `json:"criteria"`
+	// ISO 4217 code that benefit amounts and the budget are stated in
+	Currency string `json:"currency" gorm:"not null;default:SGD"`
+	// Dates are YYYY-MM-DD and inclusive, empty when the scheme has no such limit
+	EffectiveFrom       string `json:"effective_from"`
+	EffectiveTo         string `json:"effective_to"`
+	ApplicationDeadline string `json:"application_deadline"`
+	// Optional caps on the total reserved for approved applications and on their number
+	Budget        *Money `json:"budget"`
+	MaxRecipients *int   `json:"max_recipients"`
+	// Capacity reserved by approved applications, only changed through ReserveCapacity and ReleaseCapacity
+	ReservedBudget     Money          `json:"reserved_budget" gorm:"not null;default:0"`
+	ReservedRecipients int            `json:"reserved_recipients" gorm:"not null;default:0"`
+	Benefits           []Benefit      `json:"benefits" gorm:"foreignKey:SchemeID"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// SchemeVersion is an immutable snapshot of a scheme's terms, written on every create and update
+type SchemeVersion struct {
+	ID                  string      `json:"id" gorm:"type:uuid;primaryKey"`
+	SchemeID            string      `json:"scheme_id" gorm:"type:uuid;not null;uniqueIndex:idx_scheme_versions_scheme_version"`
+	Version             int         `json:"version" gorm:"not null;uniqueIndex:idx_scheme_versions_scheme_version"`
+	Name                string      `json:"name"`
+	Criteria            Criteria    `json:"criteria"`
+	Currency            string      `json:"currency"`
+	Benefits            BenefitList `json:"benefits"`
+	EffectiveFrom       string      `json:"effective_from"`
+	EffectiveTo         string      `json:"effective_to"`
+	ApplicationDeadline string      `json:"application_deadline"`
+	Budget              *Money      `json:"budget"`
+	MaxRecipients       *int        `json:"max_recipients"`
+	CreatedBy           string      `json:"created_by"`
+	CreatedAt           time.Time   `json:"created_at"`
+}
+
+// BenefitList stores the benefits of a scheme version as JSON
+type BenefitList []Benefit
+
+// UnmarshalJSON accepts both the current tree format and the
+// version 1 flat format ({"employment_status", "has_children"}),
+// upgrading the latter into an equivalent "and" group.
+func (c *Criteria) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Version          int           `json:"version"`
+		Rule             *CriteriaNode `json:"rule"`
+		EmploymentStatus string        `json:"employment_status"`
+		HasChildren      *Children     `json:"has_children"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if raw.Version >= data.CRITERIA_VERSION || raw.Rule != nil {
+		*c = Criteria{Version: data.CRITERIA_VERSION, Rule: raw.Rule}
+		return nil
+	}
+
+	var nodes []CriteriaNode
+	if raw.EmploymentStatus != "" {
+		nodes = append(nodes, CriteriaNode{
+			Type:  data.CRITERIA_NODE_EMPLOYMENT_STATUS,
+			Value: raw.EmploymentStatus,
+		})
+	}
+	if raw.HasChildren != nil && raw.HasChildren.SchoolLevel != 0 {
+		nodes = append(nodes, CriteriaNode{
+			Type:        data.CRITERIA_NODE_HAS_CHILDREN,
+			Condition:   raw.HasChildren.SchoolLevelCondition,
+			SchoolLevel: raw.HasChildren.SchoolLevel,
+		})
+	}
+
+	*c = Criteria{Version: data.CRITERIA_VERSION, upgraded: true}
+	if len(nodes) > 0 {
+		c.Rule = &CriteriaNode{Type: data.CRITERIA_NODE_AND, Nodes: nodes}
+	}
+	return nil
+}
+
+// Upgraded reports whether the criteria were read in an older format
+func (c Criteria) Upgraded() bool {
+	return c.upgraded
 }
 
 func (c *Criteria) Scan(value interface{}) error {
-	bytes, ok := value.([]byte)
-	if !ok {
-		return errors.New("failed to unmarshal JSONB value")
+	switch v := value.(type) {
+	case nil:
+		*c = Criteria{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("failed to unmarshal criteria JSON value")
+	}
+}
+
+// GormDBDataType stores criteria as jsonb on Postgres and as JSON text elsewhere
+func (Criteria) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
 	}
-	return json.Unmarshal(bytes, &c)
+	return "text"
 }
 
+// Value encodes criteria as a JSON string so it is stored as text on SQLite
 func (c Criteria) Value() (driver.Value, error) {
-	return json.Marshal(c)
+	c.Version = data.CRITERIA_VERSION
+	bytes, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+func (f *AmountFormula) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return errors.New("failed to unmarshal amount formula JSON value")
+	}
+}
+
+// GormDBDataType stores an amount formula as jsonb on Postgres and as JSON text elsewhere
+func (AmountFormula) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
+func (f AmountFormula) Value() (driver.Value, error) {
+	bytes, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+func (r *Recurrence) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	default:
+		return errors.New("failed to unmarshal recurrence JSON value")
+	}
+}
+
+// GormDBDataType stores a recurrence as jsonb on Postgres and as JSON text elsewhere
+func (Recurrence) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
+func (r Recurrence) Value() (driver.Value, error) {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+func (b *BenefitList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*b = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, b)
+	case string:
+		return json.Unmarshal([]byte(v), b)
+	default:
+		return errors.New("failed to unmarshal benefits JSON value")
+	}
+}
+
+// GormDBDataType stores benefits as jsonb on Postgres and as JSON text elsewhere
+func (BenefitList) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
+func (b BenefitList) Value() (driver.Value, error) {
+	if b == nil {
+		b = BenefitList{}
+	}
+
+	bytes, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
 }
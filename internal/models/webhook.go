@@ -0,0 +1,78 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// WebhookSubscription delivers the domain events of EventTypes to URL, signed with Secret
+type WebhookSubscription struct {
+	ID         string        `json:"id" gorm:"type:uuid;primaryKey"`
+	URL        string        `json:"url" gorm:"not null"`
+	EventTypes EventTypeList `json:"event_types" gorm:"not null"`
+	Secret     string        `json:"-" gorm:"not null"`
+	// Inactive subscriptions receive no new deliveries
+	Active    bool      `json:"active" gorm:"not null"`
+	CreatedBy string    `json:"created_by" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery is the delivery of one event to one subscription. Payload is the request body,
+// so every attempt and redelivery sends the same bytes.
+type WebhookDelivery struct {
+	ID             string   `json:"id" gorm:"type:uuid;primaryKey"`
+	SubscriptionID string   `json:"subscription_id" gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_event"`
+	EventID        string   `json:"event_id" gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_event"`
+	EventType      string   `json:"event_type" gorm:"not null"`
+	Payload        Snapshot `json:"payload" gorm:"not null"`
+	Status         string   `json:"status" gorm:"not null"`
+	Attempts       int      `json:"attempts" gorm:"not null;default:0"`
+	// Status code of the last response, zero when the request failed before a response
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LockedUntil    *time.Time `json:"-"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// EventTypeList stores the event types of a subscription as a JSON array
+type EventTypeList []string
+
+func (l *EventTypeList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return errors.New("failed to unmarshal event types JSON value")
+	}
+}
+
+// GormDBDataType stores event types as jsonb on Postgres and as JSON text elsewhere
+func (EventTypeList) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
+func (l EventTypeList) Value() (driver.Value, error) {
+	if l == nil {
+		l = EventTypeList{}
+	}
+
+	bytes, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
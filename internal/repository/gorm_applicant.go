@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormApplicantRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormApplicantRepository) Create(applicant *models.Applicant) error {
+	if err := r.db.Omit(clause.Associations).Create(applicant).Error; err != nil {
+		return err
+	}
+
+	if len(applicant.Household) > 0 {
+		return r.db.Create(&applicant.Household).Error
+	}
+
+	return nil
+}
+
+func (r *gormApplicantRepository) FindByID(id string) (*models.Applicant, error) {
+	var applicant models.Applicant
+	if err := r.db.Preload("Household").First(&applicant, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &applicant, nil
+}
+
+func (r *gormApplicantRepository) FindByIDs(ids []string) ([]models.Applicant, error) {
+	applicants := []models.Applicant{}
+	if len(ids) == 0 {
+		return applicants, nil
+	}
+
+	if err := r.db.Preload("Household").Where("id IN ?", ids).Find(&applicants).Error; err != nil {
+		return nil, err
+	}
+	return applicants, nil
+}
+
+// searchNamesSQL ranks applicant and household member names with pg_trgm. A name matches when it is
+// similar to the query as a whole (%) or when the query is similar to part of it (<%).
+const searchNamesSQL = `
+WITH matches AS (
+	SELECT id AS applicant_id, '' AS member_id, name,
+		GREATEST(similarity(name, @query), word_similarity(@query, name)) AS score
+	FROM applicants
+	WHERE deleted_at IS NULL AND (name % @query OR @query <% name)
+	UNION ALL
+	SELECT m.applicant_id, m.id::text, m.name,
+		GREATEST(similarity(m.name, @query), word_similarity(@query, m.name))
+	FROM household_members m
+	JOIN applicants a ON a.id = m.applicant_id AND a.deleted_at IS NULL
+	WHERE m.name % @query OR @query <% m.name
+), ranked AS (
+	SELECT applicant_id, MAX(score) AS best
+	FROM matches
+	GROUP BY applicant_id
+	ORDER BY best DESC, applicant_id
+	LIMIT @limit
+)
+SELECT matches.applicant_id, matches.member_id, matches.name, matches.score
+FROM matches
+JOIN ranked ON ranked.applicant_id = matches.applicant_id
+ORDER BY ranked.best DESC, matches.applicant_id, matches.score DESC, matches.member_id`
+
+func (r *gormApplicantRepository) SearchNames(query string, limit int) ([]NameMatch, error) {
+	matches := []NameMatch{}
+
+	if r.db.Dialector.Name() == "postgres" {
+		err := r.db.Raw(searchNamesSQL, map[string]interface{}{"query": query, "limit": limit}).Scan(&matches).Error
+		return matches, err
+	}
+
+	// Without pg_trgm every name is scored in Go
+	err := r.db.Raw(`SELECT id AS applicant_id, '' AS member_id, name FROM applicants WHERE deleted_at IS NULL
+		UNION ALL
+		SELECT m.applicant_id, m.id, m.name FROM household_members m
+		JOIN applicants a ON a.id = m.applicant_id AND a.deleted_at IS NULL`).Scan(&matches).Error
+	if err != nil {
+		return nil, err
+	}
+	return rankNameMatches(query, matches, limit), nil
+}
+
+func (r *gormApplicantRepository) ListPage(filter dto.ApplicantFilter, opts dto.ListOptions) ([]models.Applicant, string, error) {
+	query := filterCreated(r.db.Preload("Household"), filter.Created)
+
+	if filter.EmploymentStatus != "" {
+		query = query.Where("employment_status = ?", filter.EmploymentStatus)
+	}
+	if filter.Sex != "" {
+		query = query.Where("sex = ?", filter.Sex)
+	}
+	if filter.BornFrom != "" {
+		query = query.Where("date_of_birth >= ?", filter.BornFrom)
+	}
+	if filter.BornTo != "" {
+		query = query.Where("date_of_birth <= ?", filter.BornTo)
+	}
+
+	query, err := pageQuery(query, opts, data.APPLICANT_SORT_FIELDS)
+	if err != nil {
+		return nil, "", err
+	}
+
+	applicants := []models.Applicant{}
+	if err := query.Find(&applicants).Error; err != nil {
+		return nil, "", err
+	}
+
+	applicants, next := trimPage(applicants, opts.Limit, func(applicant models.Applicant) string {
+		return encodeCursor(applicantSortValue(applicant, opts.Sort), applicant.ID)
+	})
+	return applicants, next, nil
+}
+
+func (r *gormApplicantRepository) Update(applicant *models.Applicant) error {
+	if err := r.db.Omit(clause.Associations).Save(applicant).Error; err != nil {
+		return err
+	}
+
+	if err := r.db.Where("applicant_id = ?", applicant.ID).Delete(&models.HouseholdMember{}).Error; err != nil {
+		return err
+	}
+
+	if len(applicant.Household) > 0 {
+		return r.db.Create(&applicant.Household).Error
+	}
+
+	return nil
+}
+
+func (r *gormApplicantRepository) Delete(id string) error {
+	result := r.db.Delete(&models.Applicant{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *gormApplicantRepository) FindDeleted(id string) (*models.Applicant, error) {
+	var applicant models.Applicant
+	if err := r.db.Unscoped().Preload("Household").Where("deleted_at IS NOT NULL").First(&applicant, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &applicant, nil
+}
+
+func (r *gormApplicantRepository) Restore(id string) error {
+	return restoreDeleted(r.db, &models.Applicant{}, id)
+}
+
+func (r *gormApplicantRepository) ListDeleted(before time.Time) ([]models.Applicant, error) {
+	applicants := []models.Applicant{}
+	if err := r.db.Unscoped().Preload("Household").Where("deleted_at < ?", before).Order("deleted_at, id").Find(&applicants).Error; err != nil {
+		return nil, err
+	}
+	return applicants, nil
+}
+
+func (r *gormApplicantRepository) Purge(id string) error {
+	if err := r.db.Where("applicant_id = ?", id).Delete(&models.HouseholdMember{}).Error; err != nil {
+		return err
+	}
+
+	return purgeDeleted(r.db, &models.Applicant{}, id)
+}
+
+func (r *gormApplicantRepository) ForEachBatch(batchSize int, fn func(applicants []models.Applicant) error) error {
+	var applicants []models.Applicant
+	return r.db.Preload("Household").FindInBatches(&applicants, batchSize, func(_ *gorm.DB, _ int) error {
+		return fn(applicants)
+	}).Error
+}
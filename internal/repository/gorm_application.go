@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormApplicationRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormApplicationRepository) Create(application *models.Application) error {
+	return r.db.Create(application).Error
+}
+
+func (r *gormApplicationRepository) FindByID(id string) (*models.Application, error) {
+	var application models.Application
+	if err := r.db.First(&application, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &application, nil
+}
+
+func (r *gormApplicationRepository) FindByApplicantAndScheme(applicantID, schemeID string) (*models.Application, error) {
+	var application models.Application
+	if err := r.db.Where("applicant_id = ? AND scheme_id = ?", applicantID, schemeID).First(&application).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &application, nil
+}
+
+func (r *gormApplicationRepository) ListByApplicant(applicantID string) ([]models.Application, error) {
+	applications := []models.Application{}
+	if err := r.db.Where("applicant_id = ?", applicantID).Order("created_at, id").Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *gormApplicationRepository) ListPage(filter dto.ApplicationFilter, opts dto.ListOptions) ([]models.Application, string, error) {
+	query := filterCreated(r.db, filter.Created)
+
+	if filter.ApplicantID != "" {
+		query = query.Where("applicant_id = ?", filter.ApplicantID)
+	}
+	if filter.SchemeID != "" {
+		query = query.Where("scheme_id = ?", filter.SchemeID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	query, err := pageQuery(query, opts, data.APPLICATION_SORT_FIELDS)
+	if err != nil {
+		return nil, "", err
+	}
+
+	applications := []models.Application{}
+	if err := query.Find(&applications).Error; err != nil {
+		return nil, "", err
+	}
+
+	applications, next := trimPage(applications, opts.Limit, func(application models.Application) string {
+		return encodeCursor(applicationSortValue(application, opts.Sort), application.ID)
+	})
+	return applications, next, nil
+}
+
+func (r *gormApplicationRepository) Update(application *models.Application) error {
+	return r.db.Save(application).Error
+}
+
+func (r *gormApplicationRepository) UpdateStatus(application *models.Application, fromStatus string) (bool, error) {
+	result := r.db.Model(&models.Application{}).
+		Where("id = ? AND status = ?", application.ID, fromStatus).
+		Updates(map[string]interface{}{
+			"status":          application.Status,
+			"reserved_amount": application.ReservedAmount,
+			"approved_on":     application.ApprovedOn,
+			"updated_at":      application.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *gormApplicationRepository) Delete(id string) error {
+	result := r.db.Delete(&models.Application{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *gormApplicationRepository) DeleteByApplicantID(applicantID string) error {
+	return r.db.Where("applicant_id = ?", applicantID).Delete(&models.Application{}).Error
+}
+
+func (r *gormApplicationRepository) FindDeleted(id string) (*models.Application, error) {
+	var application models.Application
+	if err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&application, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &application, nil
+}
+
+func (r *gormApplicationRepository) Restore(id string) error {
+	return restoreDeleted(r.db, &models.Application{}, id)
+}
+
+func (r *gormApplicationRepository) RestoreByApplicantID(applicantID string, deletedSince time.Time) ([]models.Application, error) {
+	applications := []models.Application{}
+	if err := r.db.Unscoped().
+		Where("applicant_id = ? AND deleted_at >= ?", applicantID, deletedSince).
+		Order("created_at, id").
+		Find(&applications).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range applications {
+		if err := restoreDeleted(r.db, &models.Application{}, applications[i].ID); err != nil {
+			return nil, err
+		}
+		applications[i].DeletedAt = gorm.DeletedAt{}
+	}
+
+	return applications, nil
+}
+
+func (r *gormApplicationRepository) ListDeleted(before time.Time) ([]models.Application, error) {
+	applications := []models.Application{}
+	if err := r.db.Unscoped().Where("deleted_at < ?", before).Order("deleted_at, id").Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *gormApplicationRepository) HasScheme(schemeID string) (bool, error) {
+	var count int64
+	if err := r.db.Unscoped().Model(&models.Application{}).Where("scheme_id = ?", schemeID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *gormApplicationRepository) Purge(id string) error {
+	if err := r.db.Where("application_id = ?", id).Delete(&models.ApplicationStatusChange{}).Error; err != nil {
+		return err
+	}
+
+	return purgeDeleted(r.db, &models.Application{}, id)
+}
+
+func (r *gormApplicationRepository) PurgeByApplicantID(applicantID string) ([]models.Application, error) {
+	applications := []models.Application{}
+	if err := r.db.Unscoped().Where("applicant_id = ?", applicantID).Order("created_at, id").Find(&applications).Error; err != nil {
+		return nil, err
+	}
+
+	applicationIDs := r.db.Unscoped().Model(&models.Application{}).Select("id").Where("applicant_id = ?", applicantID)
+	if err := r.db.Where("application_id IN (?)", applicationIDs).Delete(&models.ApplicationStatusChange{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Unscoped().Where("applicant_id = ?", applicantID).Delete(&models.Application{}).Error; err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func (r *gormApplicationRepository) AddStatusChange(change *models.ApplicationStatusChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *gormApplicationRepository) ListStatusChanges(applicationID string) ([]models.ApplicationStatusChange, error) {
+	history := []models.ApplicationStatusChange{}
+	if err := r.db.Where("application_id = ?", applicationID).Order("changed_at").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormAuditRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormAuditRepository) Append(entry *models.AuditEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *gormAuditRepository) ListPage(filter dto.AuditFilter, opts dto.ListOptions) ([]models.AuditEntry, string, error) {
+	query := filterCreated(r.db, filter.Created)
+
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	query, err := pageQuery(query, opts, data.AUDIT_SORT_FIELDS)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries := []models.AuditEntry{}
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, "", err
+	}
+
+	entries, next := trimPage(entries, opts.Limit, func(entry models.AuditEntry) string {
+		return encodeCursor(auditSortValue(entry, opts.Sort), entry.ID)
+	})
+	return entries, next, nil
+}
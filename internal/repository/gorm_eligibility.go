@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const upsertBatchSize = 500
+
+type gormEligibilityRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormEligibilityRepository) Upsert(rows []models.ApplicantSchemeEligibility) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(rows, upsertBatchSize).Error
+}
+
+func (r *gormEligibilityRepository) ListByApplicant(applicantID string) ([]models.ApplicantSchemeEligibility, error) {
+	var rows []models.ApplicantSchemeEligibility
+	if err := r.db.Where("applicant_id = ?", applicantID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *gormEligibilityRepository) DeleteByApplicant(applicantID string) error {
+	return r.db.Where("applicant_id = ?", applicantID).Delete(&models.ApplicantSchemeEligibility{}).Error
+}
+
+func (r *gormEligibilityRepository) DeleteByScheme(schemeID string) error {
+	return r.db.Where("scheme_id = ?", schemeID).Delete(&models.ApplicantSchemeEligibility{}).Error
+}
+
+func (r *gormEligibilityRepository) ForEachApplicantToEvaluate(schemeID, referenceDate string, outdated bool, batchSize int, fn func(applicants []models.Applicant) error) error {
+	query := r.db.Preload("Household").
+		Select("applicants.*").
+		Joins("LEFT JOIN applicant_scheme_eligibilities e ON e.applicant_id = applicants.id AND e.scheme_id = ?", schemeID)
+
+	if outdated {
+		query = query.Where("(e.applicant_id IS NULL OR e.evaluated_on <> ?)", referenceDate)
+	} else {
+		query = query.Where("e.applicant_id IS NULL")
+	}
+
+	var applicants []models.Applicant
+	return query.FindInBatches(&applicants, batchSize, func(_ *gorm.DB, _ int) error {
+		return fn(applicants)
+	}).Error
+}
+
+func (r *gormEligibilityRepository) ListEligibleSchemes(applicantID string) ([]models.Scheme, error) {
+	var schemes []models.Scheme
+	if err := r.db.Preload("Benefits").
+		Select("schemes.*").
+		Joins("JOIN applicant_scheme_eligibilities e ON e.scheme_id = schemes.id").
+		Where("e.applicant_id = ? AND e.eligible = ?", applicantID, true).
+		Order("schemes.created_at, schemes.id").
+		Find(&schemes).Error; err != nil {
+		return nil, err
+	}
+	return schemes, nil
+}
+
+func (r *gormEligibilityRepository) ListEligibleApplicants(schemeID string, excludeApplied bool, offset, limit int) ([]models.Applicant, int64, error) {
+	if offset < 0 {
+		return nil, 0, ErrInvalidOffset
+	}
+
+	eligibleQuery := func() *gorm.DB {
+		query := r.db.Model(&models.Applicant{}).
+			Joins("JOIN applicant_scheme_eligibilities e ON e.applicant_id = applicants.id").
+			Where("e.scheme_id = ? AND e.eligible = ?", schemeID, true)
+
+		if excludeApplied {
+			applied := r.db.Model(&models.Application{}).Select("applicant_id").Where("scheme_id = ?", schemeID)
+			query = query.Where("applicants.id NOT IN (?)", applied)
+		}
+
+		return query
+	}
+
+	var total int64
+	if err := eligibleQuery().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var applicants []models.Applicant
+	if err := eligibleQuery().
+		Select("applicants.*").
+		Preload("Household").
+		Order("applicants.created_at, applicants.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&applicants).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return applicants, total, nil
+}
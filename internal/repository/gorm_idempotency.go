@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+// Create relies on the primary key, so of two requests creating the same key only one inserts it
+func (r *gormIdempotencyRepository) Create(record *models.IdempotencyRecord) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormIdempotencyRepository) Find(actor, key string) (*models.IdempotencyRecord, error) {
+	var record models.IdempotencyRecord
+	if err := r.db.First(&record, "actor = ? AND idempotency_key = ?", actor, key).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &record, nil
+}
+
+// Claim is a conditional update, so of two requests claiming the same key only one affects the row
+func (r *gormIdempotencyRepository) Claim(record *models.IdempotencyRecord, now, expiredBefore time.Time) (bool, error) {
+	result := r.db.Model(&models.IdempotencyRecord{}).
+		Where("actor = ? AND idempotency_key = ?", record.Actor, record.Key).
+		Where("created_at < ? OR (completed_at IS NULL AND locked_until <= ? AND request_hash = ?)", expiredBefore, now, record.RequestHash).
+		UpdateColumns(map[string]interface{}{
+			"request_hash": record.RequestHash,
+			"status_code":  0,
+			"content_type": "",
+			"body":         "",
+			"completed_at": nil,
+			"locked_until": record.LockedUntil,
+			"created_at":   record.CreatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormIdempotencyRepository) Complete(record *models.IdempotencyRecord) error {
+	return r.db.Model(&models.IdempotencyRecord{}).
+		Where("actor = ? AND idempotency_key = ?", record.Actor, record.Key).
+		UpdateColumns(map[string]interface{}{
+			"status_code":  record.StatusCode,
+			"content_type": record.ContentType,
+			"body":         record.Body,
+			"completed_at": record.CompletedAt,
+			"locked_until": nil,
+		}).Error
+}
+
+func (r *gormIdempotencyRepository) Delete(actor, key string) error {
+	return r.db.Where("actor = ? AND idempotency_key = ?", actor, key).Delete(&models.IdempotencyRecord{}).Error
+}
+
+func (r *gormIdempotencyRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
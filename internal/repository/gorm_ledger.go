@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormLedgerRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormLedgerRepository) Append(entry *models.LedgerEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *gormLedgerRepository) ListByDisbursement(disbursementID string) ([]models.LedgerEntry, error) {
+	entries := []models.LedgerEntry{}
+	if err := r.db.Where("disbursement_id = ?", disbursementID).Order("sequence").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *gormLedgerRepository) List(filter dto.LedgerFilter) ([]models.LedgerEntry, error) {
+	query := r.db
+
+	if filter.ApplicationID != "" {
+		query = query.Where("application_id = ?", filter.ApplicationID)
+	}
+	if filter.ApplicantID != "" {
+		query = query.Where("applicant_id = ?", filter.ApplicantID)
+	}
+	if filter.SchemeID != "" {
+		query = query.Where("scheme_id = ?", filter.SchemeID)
+	}
+
+	entries := []models.LedgerEntry{}
+	if err := query.Order("created_at, sequence").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
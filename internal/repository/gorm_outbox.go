@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormOutboxRepository) Append(event *models.OutboxEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *gormOutboxRepository) ListPending(now time.Time, limit int) ([]models.OutboxEvent, error) {
+	events := []models.OutboxEvent{}
+	err := r.db.Where("dispatched_at IS NULL AND next_attempt_at <= ?", now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Claim is a conditional update, so of two dispatchers claiming the same event only one affects the row.
+// The event must still be due, as another dispatcher may have attempted it since it was listed.
+func (r *gormOutboxRepository) Claim(id string, now, until time.Time) (bool, error) {
+	result := r.db.Model(&models.OutboxEvent{}).
+		Where("id = ? AND dispatched_at IS NULL AND next_attempt_at <= ?", id, now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		UpdateColumn("locked_until", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormOutboxRepository) MarkDispatched(id string, at time.Time) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"attempts":      gorm.Expr("attempts + 1"),
+		"dispatched_at": at,
+		"locked_until":  nil,
+		"last_error":    "",
+	}).Error
+}
+
+func (r *gormOutboxRepository) MarkFailed(id, lastError string, nextAttemptAt time.Time) error {
+	return r.db.Model(&models.OutboxEvent{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"next_attempt_at": nextAttemptAt,
+		"locked_until":    nil,
+		"last_error":      lastError,
+	}).Error
+}
+
+func (r *gormOutboxRepository) DeleteDispatched(before time.Time) (int64, error) {
+	result := r.db.Where("dispatched_at < ?", before).Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
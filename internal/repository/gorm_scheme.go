@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormSchemeRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormSchemeRepository) Create(scheme *models.Scheme) error {
+	if err := r.db.Omit(clause.Associations).Create(scheme).Error; err != nil {
+		return err
+	}
+
+	if len(scheme.Benefits) > 0 {
+		return r.db.Create(&scheme.Benefits).Error
+	}
+
+	return nil
+}
+
+func (r *gormSchemeRepository) FindByID(id string) (*models.Scheme, error) {
+	var scheme models.Scheme
+	if err := r.db.Preload("Benefits").First(&scheme, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &scheme, nil
+}
+
+func (r *gormSchemeRepository) List() ([]models.Scheme, error) {
+	var schemes []models.Scheme
+	if err := r.db.Preload("Benefits").Order("created_at, id").Find(&schemes).Error; err != nil {
+		return nil, err
+	}
+	return schemes, nil
+}
+
+func (r *gormSchemeRepository) ListPage(filter dto.SchemeFilter, opts dto.ListOptions) ([]models.Scheme, string, error) {
+	query := filterCreated(r.db.Preload("Benefits"), filter.Created)
+
+	if filter.Name != "" {
+		query = query.Where(`LOWER(name) LIKE ? ESCAPE '\'`, "%"+escapeLike(strings.ToLower(filter.Name))+"%")
+	}
+
+	query, err := pageQuery(query, opts, data.SCHEME_SORT_FIELDS)
+	if err != nil {
+		return nil, "", err
+	}
+
+	schemes := []models.Scheme{}
+	if err := query.Find(&schemes).Error; err != nil {
+		return nil, "", err
+	}
+
+	schemes, next := trimPage(schemes, opts.Limit, func(scheme models.Scheme) string {
+		return encodeCursor(schemeSortValue(scheme, opts.Sort), scheme.ID)
+	})
+	return schemes, next, nil
+}
+
+func (r *gormSchemeRepository) Update(scheme *models.Scheme) error {
+	// Reserved capacity is left to ReserveCapacity and ReleaseCapacity so concurrent approvals are not overwritten
+	if err := r.db.Omit(clause.Associations, "reserved_budget", "reserved_recipients").Save(scheme).Error; err != nil {
+		return err
+	}
+
+	if err := r.db.Where("scheme_id = ?", scheme.ID).Delete(&models.Benefit{}).Error; err != nil {
+		return err
+	}
+
+	if len(scheme.Benefits) > 0 {
+		return r.db.Create(&scheme.Benefits).Error
+	}
+
+	return nil
+}
+
+func (r *gormSchemeRepository) Delete(id string) error {
+	result := r.db.Delete(&models.Scheme{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *gormSchemeRepository) FindDeleted(id string) (*models.Scheme, error) {
+	var scheme models.Scheme
+	if err := r.db.Unscoped().Preload("Benefits").Where("deleted_at IS NOT NULL").First(&scheme, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &scheme, nil
+}
+
+func (r *gormSchemeRepository) Restore(id string) error {
+	return restoreDeleted(r.db, &models.Scheme{}, id)
+}
+
+func (r *gormSchemeRepository) ListDeleted(before time.Time) ([]models.Scheme, error) {
+	schemes := []models.Scheme{}
+	if err := r.db.Unscoped().Preload("Benefits").Where("deleted_at < ?", before).Order("deleted_at, id").Find(&schemes).Error; err != nil {
+		return nil, err
+	}
+	return schemes, nil
+}
+
+func (r *gormSchemeRepository) Purge(id string) error {
+	if err := r.db.Where("scheme_id = ?", id).Delete(&models.Benefit{}).Error; err != nil {
+		return err
+	}
+
+	if err := r.db.Where("scheme_id = ?", id).Delete(&models.SchemeVersion{}).Error; err != nil {
+		return err
+	}
+
+	return purgeDeleted(r.db, &models.Scheme{}, id)
+}
+
+func (r *gormSchemeRepository) ReserveCapacity(id string, amount models.Money) (bool, error) {
+	result := r.db.Model(&models.Scheme{}).
+		Where("id = ?", id).
+		Where("budget IS NULL OR reserved_budget + ? <= budget", amount).
+		Where("max_recipients IS NULL OR reserved_recipients < max_recipients").
+		Updates(map[string]interface{}{
+			"reserved_budget":     gorm.Expr("reserved_budget + ?", amount),
+			"reserved_recipients": gorm.Expr("reserved_recipients + 1"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *gormSchemeRepository) ReleaseCapacity(id string, amount models.Money) error {
+	return r.db.Unscoped().Model(&models.Scheme{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"reserved_budget":     gorm.Expr("reserved_budget - ?", amount),
+			"reserved_recipients": gorm.Expr("reserved_recipients - 1"),
+		}).Error
+}
+
+func (r *gormSchemeRepository) AddVersion(version *models.SchemeVersion) error {
+	return r.db.Create(version).Error
+}
+
+func (r *gormSchemeRepository) ListVersions(schemeID string) ([]models.SchemeVersion, error) {
+	versions := []models.SchemeVersion{}
+	if err := r.db.Where("scheme_id = ?", schemeID).Order("version").Find(&versions).Error; err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (r *gormSchemeRepository) FindVersion(schemeID string, version int) (*models.SchemeVersion, error) {
+	var schemeVersion models.SchemeVersion
+	if err := r.db.Where("scheme_id = ? AND version = ?", schemeID, version).First(&schemeVersion).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &schemeVersion, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a Store backed by the given database connection
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Applicants() ApplicantRepository {
+	return &gormApplicantRepository{db: s.db}
+}
+
+func (s *gormStore) Schemes() SchemeRepository {
+	return &gormSchemeRepository{db: s.db}
+}
+
+func (s *gormStore) Applications() ApplicationRepository {
+	return &gormApplicationRepository{db: s.db}
+}
+
+func (s *gormStore) Eligibility() EligibilityRepository {
+	return &gormEligibilityRepository{db: s.db}
+}
+
+func (s *gormStore) Audit() AuditRepository {
+	return &gormAuditRepository{db: s.db}
+}
+
+func (s *gormStore) Ledger() LedgerRepository {
+	return &gormLedgerRepository{db: s.db}
+}
+
+func (s *gormStore) Outbox() OutboxRepository {
+	return &gormOutboxRepository{db: s.db}
+}
+
+func (s *gormStore) Webhooks() WebhookRepository {
+	return &gormWebhookRepository{db: s.db}
+}
+
+func (s *gormStore) Idempotency() IdempotencyRepository {
+	return &gormIdempotencyRepository{db: s.db}
+}
+
+func (s *gormStore) Transaction(fn func(tx Store) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&gormStore{db: tx})
+	})
+}
+
+// translateError maps gorm's not found error to ErrNotFound
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// restoreDeleted clears deleted_at of a soft deleted record of model's table
+func restoreDeleted(db *gorm.DB, model interface{}, id string) error {
+	result := db.Unscoped().Model(model).Where("id = ? AND deleted_at IS NOT NULL", id).UpdateColumn("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// purgeDeleted permanently removes a soft deleted record of model's table
+func purgeDeleted(db *gorm.DB, model interface{}, id string) error {
+	result := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
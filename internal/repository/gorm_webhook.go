@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormWebhookRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormWebhookRepository) CreateSubscription(subscription *models.WebhookSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+func (r *gormWebhookRepository) FindSubscription(id string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := r.db.First(&subscription, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &subscription, nil
+}
+
+func (r *gormWebhookRepository) ListSubscriptions() ([]models.WebhookSubscription, error) {
+	subscriptions := []models.WebhookSubscription{}
+	if err := r.db.Order("created_at, id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *gormWebhookRepository) UpdateSubscription(subscription *models.WebhookSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+func (r *gormWebhookRepository) DeleteSubscription(id string) error {
+	result := r.db.Delete(&models.WebhookSubscription{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddDelivery ignores a second delivery of the same event, as the dispatcher may publish an event more than once
+func (r *gormWebhookRepository) AddDelivery(delivery *models.WebhookDelivery) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormWebhookRepository) FindDelivery(id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.First(&delivery, "id = ?", id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &delivery, nil
+}
+
+func (r *gormWebhookRepository) ListDeliveryPage(filter dto.WebhookDeliveryFilter, opts dto.ListOptions) ([]models.WebhookDelivery, string, error) {
+	query := filterCreated(r.db, filter.Created)
+
+	if filter.SubscriptionID != "" {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+
+	query, err := pageQuery(query, opts, data.WEBHOOK_DELIVERY_SORT_FIELDS)
+	if err != nil {
+		return nil, "", err
+	}
+
+	deliveries := []models.WebhookDelivery{}
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, "", err
+	}
+
+	deliveries, next := trimPage(deliveries, opts.Limit, func(delivery models.WebhookDelivery) string {
+		return encodeCursor(webhookDeliverySortValue(delivery, opts.Sort), delivery.ID)
+	})
+	return deliveries, next, nil
+}
+
+func (r *gormWebhookRepository) ListDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	deliveries := []models.WebhookDelivery{}
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", data.WEBHOOK_DELIVERY_PENDING, now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *gormWebhookRepository) ClaimDelivery(id string, now, until time.Time) (bool, error) {
+	result := r.db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, data.WEBHOOK_DELIVERY_PENDING, now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		UpdateColumn("locked_until", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *gormWebhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	delivery.LockedUntil = nil
+	return r.db.Save(delivery).Error
+}
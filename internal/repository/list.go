@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// sortTimeLayout is fixed width so formatted times order the same way as the times
+const sortTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// timeSortFields are sort fields backed by timestamp columns
+var timeSortFields = map[string]bool{
+	data.SORT_CREATED_AT: true,
+	data.SORT_UPDATED_AT: true,
+}
+
+// cursor identifies the last record of a page by its sort value and ID
+type cursor struct {
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func encodeCursor(value, id string) string {
+	bytes, _ := json.Marshal(cursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func decodeCursor(encoded string) (*cursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(bytes, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func formatSortTime(t time.Time) string {
+	return t.UTC().Format(sortTimeLayout)
+}
+
+/* Sort Values */
+
+func applicantSortValue(applicant models.Applicant, field string) string {
+	switch field {
+	case data.SORT_NAME:
+		return applicant.Name
+	case data.SORT_DATE_OF_BIRTH:
+		return applicant.DateOfBirth
+	case data.SORT_UPDATED_AT:
+		return formatSortTime(applicant.UpdatedAt)
+	default:
+		return formatSortTime(applicant.CreatedAt)
+	}
+}
+
+func schemeSortValue(scheme models.Scheme, field string) string {
+	switch field {
+	case data.SORT_NAME:
+		return scheme.Name
+	case data.SORT_UPDATED_AT:
+		return formatSortTime(scheme.UpdatedAt)
+	default:
+		return formatSortTime(scheme.CreatedAt)
+	}
+}
+
+func applicationSortValue(application models.Application, field string) string {
+	switch field {
+	case data.SORT_STATUS:
+		return application.Status
+	case data.SORT_UPDATED_AT:
+		return formatSortTime(application.UpdatedAt)
+	default:
+		return formatSortTime(application.CreatedAt)
+	}
+}
+
+func auditSortValue(entry models.AuditEntry, field string) string {
+	return formatSortTime(entry.CreatedAt)
+}
+
+func webhookDeliverySortValue(delivery models.WebhookDelivery, field string) string {
+	if field == data.SORT_UPDATED_AT {
+		return formatSortTime(delivery.UpdatedAt)
+	}
+	return formatSortTime(delivery.CreatedAt)
+}
+
+/* Paging */
+
+// trimPage drops the extra record fetched to detect a following page and returns the cursor for it
+func trimPage[T any](records []T, limit int, cursorOf func(T) string) ([]T, string) {
+	if len(records) <= limit {
+		return records, ""
+	}
+
+	records = records[:limit]
+	return records, cursorOf(records[limit-1])
+}
+
+// pageQuery orders the query by the sort column and ID and continues after the cursor.
+// allowed maps the sort fields of the table to their column names.
+func pageQuery(query *gorm.DB, opts dto.ListOptions, allowed map[string]bool) (*gorm.DB, error) {
+	if !allowed[opts.Sort] {
+		return nil, fmt.Errorf("invalid sort field: '%s'", opts.Sort)
+	}
+
+	column, direction, comparison := opts.Sort, "ASC", ">"
+	if opts.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if opts.Cursor != "" {
+		after, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{} = after.Value
+		if timeSortFields[column] {
+			t, err := time.Parse(sortTimeLayout, after.Value)
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			value = t
+		}
+
+		query = query.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, comparison),
+			value, value, after.ID,
+		)
+	}
+
+	return query.Order(fmt.Sprintf("%[1]s %[2]s, id %[2]s", column, direction)).Limit(opts.Limit + 1), nil
+}
+
+func filterCreated(query *gorm.DB, created dto.CreatedRange) *gorm.DB {
+	if created.From != nil {
+		query = query.Where("created_at >= ?", *created.From)
+	}
+	if created.Before != nil {
+		query = query.Where("created_at < ?", *created.Before)
+	}
+	return query
+}
+
+// escapeLike escapes the LIKE wildcards in a search term, use with ESCAPE '\'
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
+
+/* In-Memory Paging */
+
+// memoryPage sorts records like pageQuery, skips up to the cursor and returns one page
+func memoryPage[T any](records []T, opts dto.ListOptions, allowed map[string]bool, sortValue func(T, string) string, idOf func(T) string) ([]T, string, error) {
+	if !allowed[opts.Sort] {
+		return nil, "", fmt.Errorf("invalid sort field: '%s'", opts.Sort)
+	}
+
+	// before reports whether record a comes first in the requested order
+	before := func(aValue, aID, bValue, bID string) bool {
+		if aValue != bValue {
+			return (aValue < bValue) != opts.Desc
+		}
+		if aID != bID {
+			return (aID < bID) != opts.Desc
+		}
+		return false
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return before(sortValue(records[i], opts.Sort), idOf(records[i]), sortValue(records[j], opts.Sort), idOf(records[j]))
+	})
+
+	if opts.Cursor != "" {
+		after, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		start := len(records)
+		for i, record := range records {
+			if before(after.Value, after.ID, sortValue(record, opts.Sort), idOf(record)) {
+				start = i
+				break
+			}
+		}
+		records = records[start:]
+	}
+
+	if len(records) > opts.Limit+1 {
+		records = records[:opts.Limit+1]
+	}
+
+	page, next := trimPage(records, opts.Limit, func(record T) string {
+		return encodeCursor(sortValue(record, opts.Sort), idOf(record))
+	})
+	return page, next, nil
+}
+
+func inCreatedRange(createdAt time.Time, created dto.CreatedRange) bool {
+	if created.From != nil && createdAt.Before(*created.From) {
+		return false
+	}
+	if created.Before != nil && !createdAt.Before(*created.Before) {
+		return false
+	}
+	return true
+}
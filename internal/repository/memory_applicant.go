@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type memoryApplicantRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryApplicantRepository) Create(applicant *models.Applicant) error {
+	defer r.store.lock()()
+
+	r.store.state.applicants[applicant.ID] = copyApplicant(*applicant)
+	return nil
+}
+
+func (r *memoryApplicantRepository) FindByID(id string) (*models.Applicant, error) {
+	defer r.store.lock()()
+
+	applicant, ok := r.store.state.applicants[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	applicant = copyApplicant(applicant)
+	return &applicant, nil
+}
+
+func (r *memoryApplicantRepository) FindByIDs(ids []string) ([]models.Applicant, error) {
+	defer r.store.lock()()
+
+	applicants := []models.Applicant{}
+	for _, id := range ids {
+		if applicant, ok := r.store.state.applicants[id]; ok {
+			applicants = append(applicants, copyApplicant(applicant))
+		}
+	}
+	return applicants, nil
+}
+
+func (r *memoryApplicantRepository) SearchNames(query string, limit int) ([]NameMatch, error) {
+	defer r.store.lock()()
+
+	var candidates []NameMatch
+	for _, applicant := range r.store.state.applicants {
+		candidates = append(candidates, NameMatch{ApplicantID: applicant.ID, Name: applicant.Name})
+		for _, member := range applicant.Household {
+			candidates = append(candidates, NameMatch{ApplicantID: applicant.ID, MemberID: member.ID, Name: member.Name})
+		}
+	}
+
+	return rankNameMatches(query, candidates, limit), nil
+}
+
+func (r *memoryApplicantRepository) ListPage(filter dto.ApplicantFilter, opts dto.ListOptions) ([]models.Applicant, string, error) {
+	defer r.store.lock()()
+
+	applicants := []models.Applicant{}
+	for _, applicant := range r.store.state.applicants {
+		if filter.EmploymentStatus != "" && applicant.EmploymentStatus != filter.EmploymentStatus {
+			continue
+		}
+		if filter.Sex != "" && applicant.Sex != filter.Sex {
+			continue
+		}
+		if filter.BornFrom != "" && applicant.DateOfBirth < filter.BornFrom {
+			continue
+		}
+		if filter.BornTo != "" && applicant.DateOfBirth > filter.BornTo {
+			continue
+		}
+		if !inCreatedRange(applicant.CreatedAt, filter.Created) {
+			continue
+		}
+		applicants = append(applicants, copyApplicant(applicant))
+	}
+
+	return memoryPage(applicants, opts, data.APPLICANT_SORT_FIELDS, applicantSortValue, func(applicant models.Applicant) string {
+		return applicant.ID
+	})
+}
+
+func (r *memoryApplicantRepository) Update(applicant *models.Applicant) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.applicants[applicant.ID]; !ok {
+		return ErrNotFound
+	}
+
+	r.store.state.applicants[applicant.ID] = copyApplicant(*applicant)
+	return nil
+}
+
+func (r *memoryApplicantRepository) Delete(id string) error {
+	defer r.store.lock()()
+
+	applicant, ok := r.store.state.applicants[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	applicant.DeletedAt = deletedNow()
+	r.store.state.deletedApplicants[id] = applicant
+	delete(r.store.state.applicants, id)
+	return nil
+}
+
+func (r *memoryApplicantRepository) FindDeleted(id string) (*models.Applicant, error) {
+	defer r.store.lock()()
+
+	applicant, ok := r.store.state.deletedApplicants[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	applicant = copyApplicant(applicant)
+	return &applicant, nil
+}
+
+func (r *memoryApplicantRepository) Restore(id string) error {
+	defer r.store.lock()()
+
+	applicant, ok := r.store.state.deletedApplicants[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	applicant.DeletedAt = gorm.DeletedAt{}
+	r.store.state.applicants[id] = applicant
+	delete(r.store.state.deletedApplicants, id)
+	return nil
+}
+
+func (r *memoryApplicantRepository) ListDeleted(before time.Time) ([]models.Applicant, error) {
+	defer r.store.lock()()
+
+	return deletedBefore(r.store.state.deletedApplicants, before,
+		func(applicant models.Applicant) gorm.DeletedAt { return applicant.DeletedAt },
+		func(applicant models.Applicant) string { return applicant.ID },
+		copyApplicant,
+	), nil
+}
+
+func (r *memoryApplicantRepository) Purge(id string) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.deletedApplicants[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.store.state.deletedApplicants, id)
+	return nil
+}
+
+func (r *memoryApplicantRepository) ForEachBatch(batchSize int, fn func(applicants []models.Applicant) error) error {
+	unlock := r.store.lock()
+	applicants := sortedApplicants(r.store.state)
+	unlock()
+
+	return forEachBatch(applicants, batchSize, fn)
+}
+
+// sortedApplicants returns copies of every applicant ordered like the gorm repository
+func sortedApplicants(state *memoryState) []models.Applicant {
+	applicants := make([]models.Applicant, 0, len(state.applicants))
+	for _, applicant := range state.applicants {
+		applicants = append(applicants, copyApplicant(applicant))
+	}
+
+	sort.Slice(applicants, func(i, j int) bool {
+		if !applicants[i].CreatedAt.Equal(applicants[j].CreatedAt) {
+			return applicants[i].CreatedAt.Before(applicants[j].CreatedAt)
+		}
+		return applicants[i].ID < applicants[j].ID
+	})
+
+	return applicants
+}
+
+func forEachBatch(applicants []models.Applicant, batchSize int, fn func(applicants []models.Applicant) error) error {
+	for start := 0; start < len(applicants); start += batchSize {
+		end := start + batchSize
+		if end > len(applicants) {
+			end = len(applicants)
+		}
+
+		if err := fn(applicants[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
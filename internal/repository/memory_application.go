@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type memoryApplicationRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryApplicationRepository) Create(application *models.Application) error {
+	defer r.store.lock()()
+
+	r.store.state.applications[application.ID] = *application
+	return nil
+}
+
+func (r *memoryApplicationRepository) FindByID(id string) (*models.Application, error) {
+	defer r.store.lock()()
+
+	application, ok := r.store.state.applications[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &application, nil
+}
+
+func (r *memoryApplicationRepository) FindByApplicantAndScheme(applicantID, schemeID string) (*models.Application, error) {
+	defer r.store.lock()()
+
+	for _, application := range r.store.state.applications {
+		if application.ApplicantID == applicantID && application.SchemeID == schemeID {
+			return &application, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryApplicationRepository) ListByApplicant(applicantID string) ([]models.Application, error) {
+	defer r.store.lock()()
+
+	applications := []models.Application{}
+	for _, application := range r.store.state.applications {
+		if application.ApplicantID == applicantID {
+			applications = append(applications, application)
+		}
+	}
+
+	sortApplications(applications)
+	return applications, nil
+}
+
+func (r *memoryApplicationRepository) ListPage(filter dto.ApplicationFilter, opts dto.ListOptions) ([]models.Application, string, error) {
+	defer r.store.lock()()
+
+	applications := []models.Application{}
+	for _, application := range r.store.state.applications {
+		if filter.ApplicantID != "" && application.ApplicantID != filter.ApplicantID {
+			continue
+		}
+		if filter.SchemeID != "" && application.SchemeID != filter.SchemeID {
+			continue
+		}
+		if filter.Status != "" && application.Status != filter.Status {
+			continue
+		}
+		if !inCreatedRange(application.CreatedAt, filter.Created) {
+			continue
+		}
+		applications = append(applications, application)
+	}
+
+	return memoryPage(applications, opts, data.APPLICATION_SORT_FIELDS, applicationSortValue, func(application models.Application) string {
+		return application.ID
+	})
+}
+
+func (r *memoryApplicationRepository) Update(application *models.Application) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.applications[application.ID]; !ok {
+		return ErrNotFound
+	}
+
+	r.store.state.applications[application.ID] = *application
+	return nil
+}
+
+func (r *memoryApplicationRepository) UpdateStatus(application *models.Application, fromStatus string) (bool, error) {
+	defer r.store.lock()()
+
+	stored, ok := r.store.state.applications[application.ID]
+	if !ok || stored.Status != fromStatus {
+		return false, nil
+	}
+
+	stored.Status = application.Status
+	stored.ReservedAmount = application.ReservedAmount
+	stored.ApprovedOn = application.ApprovedOn
+	stored.UpdatedAt = application.UpdatedAt
+	r.store.state.applications[application.ID] = stored
+	return true, nil
+}
+
+func (r *memoryApplicationRepository) Delete(id string) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.applications[id]; !ok {
+		return ErrNotFound
+	}
+
+	r.store.state.softDeleteApplication(id, deletedNow())
+	return nil
+}
+
+func (r *memoryApplicationRepository) DeleteByApplicantID(applicantID string) error {
+	defer r.store.lock()()
+
+	deletedAt := deletedNow()
+	for id, application := range r.store.state.applications {
+		if application.ApplicantID == applicantID {
+			r.store.state.softDeleteApplication(id, deletedAt)
+		}
+	}
+	return nil
+}
+
+func (r *memoryApplicationRepository) FindDeleted(id string) (*models.Application, error) {
+	defer r.store.lock()()
+
+	application, ok := r.store.state.deletedApplications[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &application, nil
+}
+
+func (r *memoryApplicationRepository) Restore(id string) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.deletedApplications[id]; !ok {
+		return ErrNotFound
+	}
+
+	r.store.state.restoreApplication(id)
+	return nil
+}
+
+func (r *memoryApplicationRepository) RestoreByApplicantID(applicantID string, deletedSince time.Time) ([]models.Application, error) {
+	defer r.store.lock()()
+
+	restored := []models.Application{}
+	for id, application := range r.store.state.deletedApplications {
+		if application.ApplicantID == applicantID && !application.DeletedAt.Time.Before(deletedSince) {
+			restored = append(restored, r.store.state.restoreApplication(id))
+		}
+	}
+
+	sortApplications(restored)
+	return restored, nil
+}
+
+func (r *memoryApplicationRepository) ListDeleted(before time.Time) ([]models.Application, error) {
+	defer r.store.lock()()
+
+	return deletedBefore(r.store.state.deletedApplications, before,
+		func(application models.Application) gorm.DeletedAt { return application.DeletedAt },
+		func(application models.Application) string { return application.ID },
+		func(application models.Application) models.Application { return application },
+	), nil
+}
+
+func (r *memoryApplicationRepository) HasScheme(schemeID string) (bool, error) {
+	defer r.store.lock()()
+
+	for _, applications := range []map[string]models.Application{r.store.state.applications, r.store.state.deletedApplications} {
+		for _, application := range applications {
+			if application.SchemeID == schemeID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryApplicationRepository) Purge(id string) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.deletedApplications[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.store.state.deletedApplications, id)
+	r.store.state.removeStatusChanges(map[string]bool{id: true})
+	return nil
+}
+
+func (r *memoryApplicationRepository) PurgeByApplicantID(applicantID string) ([]models.Application, error) {
+	defer r.store.lock()()
+
+	purged := []models.Application{}
+	purgedIDs := map[string]bool{}
+	for _, applications := range []map[string]models.Application{r.store.state.applications, r.store.state.deletedApplications} {
+		for id, application := range applications {
+			if application.ApplicantID == applicantID {
+				purged = append(purged, application)
+				purgedIDs[id] = true
+				delete(applications, id)
+			}
+		}
+	}
+
+	r.store.state.removeStatusChanges(purgedIDs)
+	sortApplications(purged)
+	return purged, nil
+}
+
+func (r *memoryApplicationRepository) AddStatusChange(change *models.ApplicationStatusChange) error {
+	defer r.store.lock()()
+
+	r.store.state.statusChanges = append(r.store.state.statusChanges, *change)
+	return nil
+}
+
+func (r *memoryApplicationRepository) ListStatusChanges(applicationID string) ([]models.ApplicationStatusChange, error) {
+	defer r.store.lock()()
+
+	history := []models.ApplicationStatusChange{}
+	for _, change := range r.store.state.statusChanges {
+		if change.ApplicationID == applicationID {
+			history = append(history, change)
+		}
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].ChangedAt.Before(history[j].ChangedAt)
+	})
+
+	return history, nil
+}
+
+func (s *memoryState) removeStatusChanges(applicationIDs map[string]bool) {
+	kept := s.statusChanges[:0]
+	for _, change := range s.statusChanges {
+		if !applicationIDs[change.ApplicationID] {
+			kept = append(kept, change)
+		}
+	}
+	s.statusChanges = kept
+}
+
+func (s *memoryState) softDeleteApplication(id string, deletedAt gorm.DeletedAt) {
+	application := s.applications[id]
+	application.DeletedAt = deletedAt
+	s.deletedApplications[id] = application
+	delete(s.applications, id)
+}
+
+func (s *memoryState) restoreApplication(id string) models.Application {
+	application := s.deletedApplications[id]
+	application.DeletedAt = gorm.DeletedAt{}
+	s.applications[id] = application
+	delete(s.deletedApplications, id)
+	return application
+}
+
+// sortApplications orders applications like the gorm repository, by creation time and ID
+func sortApplications(applications []models.Application) {
+	sort.Slice(applications, func(i, j int) bool {
+		if !applications[i].CreatedAt.Equal(applications[j].CreatedAt) {
+			return applications[i].CreatedAt.Before(applications[j].CreatedAt)
+		}
+		return applications[i].ID < applications[j].ID
+	})
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+type memoryAuditRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryAuditRepository) Append(entry *models.AuditEntry) error {
+	defer r.store.lock()()
+
+	r.store.state.auditLog = append(r.store.state.auditLog, *entry)
+	return nil
+}
+
+func (r *memoryAuditRepository) ListPage(filter dto.AuditFilter, opts dto.ListOptions) ([]models.AuditEntry, string, error) {
+	defer r.store.lock()()
+
+	entries := []models.AuditEntry{}
+	for _, entry := range r.store.state.auditLog {
+		if filter.Actor != "" && entry.Actor != filter.Actor {
+			continue
+		}
+		if filter.EntityType != "" && entry.EntityType != filter.EntityType {
+			continue
+		}
+		if filter.EntityID != "" && entry.EntityID != filter.EntityID {
+			continue
+		}
+		if filter.Action != "" && entry.Action != filter.Action {
+			continue
+		}
+		if !inCreatedRange(entry.CreatedAt, filter.Created) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return memoryPage(entries, opts, data.AUDIT_SORT_FIELDS, auditSortValue, func(entry models.AuditEntry) string {
+		return entry.ID
+	})
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+type memoryEligibilityRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryEligibilityRepository) Upsert(rows []models.ApplicantSchemeEligibility) error {
+	defer r.store.lock()()
+
+	for _, row := range rows {
+		r.store.state.eligibility[eligibilityKey{row.ApplicantID, row.SchemeID}] = row
+	}
+	return nil
+}
+
+func (r *memoryEligibilityRepository) ListByApplicant(applicantID string) ([]models.ApplicantSchemeEligibility, error) {
+	defer r.store.lock()()
+
+	var rows []models.ApplicantSchemeEligibility
+	for key, row := range r.store.state.eligibility {
+		if key.applicantID == applicantID {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (r *memoryEligibilityRepository) DeleteByApplicant(applicantID string) error {
+	defer r.store.lock()()
+
+	for key := range r.store.state.eligibility {
+		if key.applicantID == applicantID {
+			delete(r.store.state.eligibility, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryEligibilityRepository) DeleteByScheme(schemeID string) error {
+	defer r.store.lock()()
+
+	for key := range r.store.state.eligibility {
+		if key.schemeID == schemeID {
+			delete(r.store.state.eligibility, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryEligibilityRepository) ForEachApplicantToEvaluate(schemeID, referenceDate string, outdated bool, batchSize int, fn func(applicants []models.Applicant) error) error {
+	unlock := r.store.lock()
+	var applicants []models.Applicant
+	for _, applicant := range sortedApplicants(r.store.state) {
+		row, ok := r.store.state.eligibility[eligibilityKey{applicant.ID, schemeID}]
+		if !ok || (outdated && row.EvaluatedOn != referenceDate) {
+			applicants = append(applicants, applicant)
+		}
+	}
+	unlock()
+
+	return forEachBatch(applicants, batchSize, fn)
+}
+
+func (r *memoryEligibilityRepository) ListEligibleSchemes(applicantID string) ([]models.Scheme, error) {
+	defer r.store.lock()()
+
+	state := r.store.state
+	return sortedSchemes(state, func(scheme models.Scheme) bool {
+		return state.eligibility[eligibilityKey{applicantID, scheme.ID}].Eligible
+	}), nil
+}
+
+func (r *memoryEligibilityRepository) ListEligibleApplicants(schemeID string, excludeApplied bool, offset, limit int) ([]models.Applicant, int64, error) {
+	if offset < 0 {
+		return nil, 0, ErrInvalidOffset
+	}
+
+	defer r.store.lock()()
+
+	applied := map[string]bool{}
+	if excludeApplied {
+		for _, application := range r.store.state.applications {
+			if application.SchemeID == schemeID {
+				applied[application.ApplicantID] = true
+			}
+		}
+	}
+
+	eligible := []models.Applicant{}
+	for _, applicant := range sortedApplicants(r.store.state) {
+		if r.store.state.eligibility[eligibilityKey{applicant.ID, schemeID}].Eligible && !applied[applicant.ID] {
+			eligible = append(eligible, applicant)
+		}
+	}
+
+	total := int64(len(eligible))
+	if offset >= len(eligible) {
+		return []models.Applicant{}, total, nil
+	}
+
+	end := offset + limit
+	if end > len(eligible) {
+		end = len(eligible)
+	}
+
+	return eligible[offset:end], total, nil
+}
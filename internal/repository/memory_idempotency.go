@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+type memoryIdempotencyRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryIdempotencyRepository) Create(record *models.IdempotencyRecord) (bool, error) {
+	defer r.store.lock()()
+
+	key := idempotencyKey{actor: record.Actor, key: record.Key}
+	if _, ok := r.store.state.idempotency[key]; ok {
+		return false, nil
+	}
+	r.store.state.idempotency[key] = *record
+	return true, nil
+}
+
+func (r *memoryIdempotencyRepository) Find(actor, key string) (*models.IdempotencyRecord, error) {
+	defer r.store.lock()()
+
+	record, ok := r.store.state.idempotency[idempotencyKey{actor: actor, key: key}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &record, nil
+}
+
+func (r *memoryIdempotencyRepository) Claim(record *models.IdempotencyRecord, now, expiredBefore time.Time) (bool, error) {
+	defer r.store.lock()()
+
+	key := idempotencyKey{actor: record.Actor, key: record.Key}
+	existing, ok := r.store.state.idempotency[key]
+	if !ok {
+		return false, nil
+	}
+
+	expired := existing.CreatedAt.Before(expiredBefore)
+	abandoned := existing.CompletedAt == nil && existing.LockedUntil != nil && !existing.LockedUntil.After(now) &&
+		existing.RequestHash == record.RequestHash
+	if !expired && !abandoned {
+		return false, nil
+	}
+
+	r.store.state.idempotency[key] = *record
+	return true, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(record *models.IdempotencyRecord) error {
+	defer r.store.lock()()
+
+	key := idempotencyKey{actor: record.Actor, key: record.Key}
+	existing, ok := r.store.state.idempotency[key]
+	if !ok {
+		return nil
+	}
+
+	existing.StatusCode = record.StatusCode
+	existing.ContentType = record.ContentType
+	existing.Body = record.Body
+	existing.CompletedAt = record.CompletedAt
+	existing.LockedUntil = nil
+	r.store.state.idempotency[key] = existing
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Delete(actor, key string) error {
+	defer r.store.lock()()
+
+	delete(r.store.state.idempotency, idempotencyKey{actor: actor, key: key})
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(before time.Time) (int64, error) {
+	defer r.store.lock()()
+
+	var deleted int64
+	for key, record := range r.store.state.idempotency {
+		if record.CreatedAt.Before(before) {
+			delete(r.store.state.idempotency, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+type memoryLedgerRepository struct {
+	store *MemoryStore
+}
+
+// Entries are appended in order, so the ledger is already ordered by creation and sequence
+func (r *memoryLedgerRepository) Append(entry *models.LedgerEntry) error {
+	defer r.store.lock()()
+
+	for _, existing := range r.store.state.ledger {
+		if existing.DisbursementID == entry.DisbursementID && existing.Sequence == entry.Sequence {
+			return errors.New("ledger entry already exists")
+		}
+	}
+
+	r.store.state.ledger = append(r.store.state.ledger, *entry)
+	return nil
+}
+
+func (r *memoryLedgerRepository) ListByDisbursement(disbursementID string) ([]models.LedgerEntry, error) {
+	defer r.store.lock()()
+
+	entries := []models.LedgerEntry{}
+	for _, entry := range r.store.state.ledger {
+		if entry.DisbursementID == disbursementID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (r *memoryLedgerRepository) List(filter dto.LedgerFilter) ([]models.LedgerEntry, error) {
+	defer r.store.lock()()
+
+	entries := []models.LedgerEntry{}
+	for _, entry := range r.store.state.ledger {
+		if filter.ApplicationID != "" && entry.ApplicationID != filter.ApplicationID {
+			continue
+		}
+		if filter.ApplicantID != "" && entry.ApplicantID != filter.ApplicantID {
+			continue
+		}
+		if filter.SchemeID != "" && entry.SchemeID != filter.SchemeID {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+type memoryOutboxRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryOutboxRepository) Append(event *models.OutboxEvent) error {
+	defer r.store.lock()()
+
+	r.store.state.outbox = append(r.store.state.outbox, *event)
+	return nil
+}
+
+// ListPending relies on events being appended in creation order
+func (r *memoryOutboxRepository) ListPending(now time.Time, limit int) ([]models.OutboxEvent, error) {
+	defer r.store.lock()()
+
+	events := []models.OutboxEvent{}
+	for _, event := range r.store.state.outbox {
+		if len(events) == limit {
+			break
+		}
+		if event.DispatchedAt == nil && !event.NextAttemptAt.After(now) && !outboxLocked(event, now) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (r *memoryOutboxRepository) Claim(id string, now, until time.Time) (bool, error) {
+	claimed := false
+	err := r.update(id, func(event *models.OutboxEvent) {
+		if event.DispatchedAt == nil && !event.NextAttemptAt.After(now) && !outboxLocked(*event, now) {
+			event.LockedUntil = &until
+			claimed = true
+		}
+	})
+	return claimed, err
+}
+
+func (r *memoryOutboxRepository) MarkDispatched(id string, at time.Time) error {
+	return r.update(id, func(event *models.OutboxEvent) {
+		event.Attempts++
+		event.DispatchedAt = &at
+		event.LockedUntil = nil
+		event.LastError = ""
+	})
+}
+
+func (r *memoryOutboxRepository) MarkFailed(id, lastError string, nextAttemptAt time.Time) error {
+	return r.update(id, func(event *models.OutboxEvent) {
+		event.Attempts++
+		event.NextAttemptAt = nextAttemptAt
+		event.LockedUntil = nil
+		event.LastError = lastError
+	})
+}
+
+func (r *memoryOutboxRepository) DeleteDispatched(before time.Time) (int64, error) {
+	defer r.store.lock()()
+
+	var deleted int64
+	kept := []models.OutboxEvent{}
+	for _, event := range r.store.state.outbox {
+		if event.DispatchedAt != nil && event.DispatchedAt.Before(before) {
+			deleted++
+			continue
+		}
+		kept = append(kept, event)
+	}
+	r.store.state.outbox = kept
+	return deleted, nil
+}
+
+func (r *memoryOutboxRepository) update(id string, fn func(event *models.OutboxEvent)) error {
+	defer r.store.lock()()
+
+	for i := range r.store.state.outbox {
+		if r.store.state.outbox[i].ID == id {
+			fn(&r.store.state.outbox[i])
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func outboxLocked(event models.OutboxEvent, now time.Time) bool {
+	return event.LockedUntil != nil && event.LockedUntil.After(now)
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type memorySchemeRepository struct {
+	store *MemoryStore
+}
+
+func (r *memorySchemeRepository) Create(scheme *models.Scheme) error {
+	defer r.store.lock()()
+
+	r.store.state.schemes[scheme.ID] = copyScheme(*scheme)
+	return nil
+}
+
+func (r *memorySchemeRepository) FindByID(id string) (*models.Scheme, error) {
+	defer r.store.lock()()
+
+	scheme, ok := r.store.state.schemes[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	scheme = copyScheme(scheme)
+	return &scheme, nil
+}
+
+func (r *memorySchemeRepository) List() ([]models.Scheme, error) {
+	defer r.store.lock()()
+
+	return sortedSchemes(r.store.state, func(models.Scheme) bool { return true }), nil
+}
+
+func (r *memorySchemeRepository) ListPage(filter dto.SchemeFilter, opts dto.ListOptions) ([]models.Scheme, string, error) {
+	defer r.store.lock()()
+
+	name := strings.ToLower(filter.Name)
+	schemes := sortedSchemes(r.store.state, func(scheme models.Scheme) bool {
+		return strings.Contains(strings.ToLower(scheme.Name), name) && inCreatedRange(scheme.CreatedAt, filter.Created)
+	})
+
+	return memoryPage(schemes, opts, data.SCHEME_SORT_FIELDS, schemeSortValue, func(scheme models.Scheme) string {
+		return scheme.ID
+	})
+}
+
+func (r *memorySchemeRepository) Update(scheme *models.Scheme) error {
+	defer r.store.lock()()
+
+	stored, ok := r.store.state.schemes[scheme.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	updated := copyScheme(*scheme)
+	updated.ReservedBudget = stored.ReservedBudget
+	updated.ReservedRecipients = stored.ReservedRecipients
+	r.store.state.schemes[scheme.ID] = updated
+	return nil
+}
+
+func (r *memorySchemeRepository) Delete(id string) error {
+	defer r.store.lock()()
+
+	scheme, ok := r.store.state.schemes[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	scheme.DeletedAt = deletedNow()
+	r.store.state.deletedSchemes[id] = scheme
+	delete(r.store.state.schemes, id)
+	return nil
+}
+
+func (r *memorySchemeRepository) FindDeleted(id string) (*models.Scheme, error) {
+	defer r.store.lock()()
+
+	scheme, ok := r.store.state.deletedSchemes[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	scheme = copyScheme(scheme)
+	return &scheme, nil
+}
+
+func (r *memorySchemeRepository) Restore(id string) error {
+	defer r.store.lock()()
+
+	scheme, ok := r.store.state.deletedSchemes[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	scheme.DeletedAt = gorm.DeletedAt{}
+	r.store.state.schemes[id] = scheme
+	delete(r.store.state.deletedSchemes, id)
+	return nil
+}
+
+func (r *memorySchemeRepository) ListDeleted(before time.Time) ([]models.Scheme, error) {
+	defer r.store.lock()()
+
+	return deletedBefore(r.store.state.deletedSchemes, before,
+		func(scheme models.Scheme) gorm.DeletedAt { return scheme.DeletedAt },
+		func(scheme models.Scheme) string { return scheme.ID },
+		copyScheme,
+	), nil
+}
+
+func (r *memorySchemeRepository) Purge(id string) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.deletedSchemes[id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(r.store.state.deletedSchemes, id)
+
+	kept := r.store.state.schemeVersions[:0]
+	for _, version := range r.store.state.schemeVersions {
+		if version.SchemeID != id {
+			kept = append(kept, version)
+		}
+	}
+	r.store.state.schemeVersions = kept
+	return nil
+}
+
+func (r *memorySchemeRepository) ReserveCapacity(id string, amount models.Money) (bool, error) {
+	defer r.store.lock()()
+
+	scheme, ok := r.store.state.schemes[id]
+	if !ok {
+		return false, nil
+	}
+
+	if scheme.Budget != nil && scheme.ReservedBudget+amount > *scheme.Budget {
+		return false, nil
+	}
+	if scheme.MaxRecipients != nil && scheme.ReservedRecipients >= *scheme.MaxRecipients {
+		return false, nil
+	}
+
+	scheme.ReservedBudget += amount
+	scheme.ReservedRecipients++
+	r.store.state.schemes[id] = scheme
+	return true, nil
+}
+
+func (r *memorySchemeRepository) ReleaseCapacity(id string, amount models.Money) error {
+	defer r.store.lock()()
+
+	schemes := r.store.state.schemes
+	if _, ok := schemes[id]; !ok {
+		schemes = r.store.state.deletedSchemes
+	}
+
+	// Like the SQL update, releasing capacity of a purged scheme is a no-op
+	scheme, ok := schemes[id]
+	if !ok {
+		return nil
+	}
+
+	scheme.ReservedBudget -= amount
+	scheme.ReservedRecipients--
+	schemes[id] = scheme
+	return nil
+}
+
+func (r *memorySchemeRepository) AddVersion(version *models.SchemeVersion) error {
+	defer r.store.lock()()
+
+	for _, existing := range r.store.state.schemeVersions {
+		if existing.SchemeID == version.SchemeID && existing.Version == version.Version {
+			return errors.New("scheme version already exists")
+		}
+	}
+
+	r.store.state.schemeVersions = append(r.store.state.schemeVersions, copySchemeVersion(*version))
+	return nil
+}
+
+func (r *memorySchemeRepository) ListVersions(schemeID string) ([]models.SchemeVersion, error) {
+	defer r.store.lock()()
+
+	versions := []models.SchemeVersion{}
+	for _, version := range r.store.state.schemeVersions {
+		if version.SchemeID == schemeID {
+			versions = append(versions, copySchemeVersion(version))
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+	return versions, nil
+}
+
+func (r *memorySchemeRepository) FindVersion(schemeID string, version int) (*models.SchemeVersion, error) {
+	defer r.store.lock()()
+
+	for _, existing := range r.store.state.schemeVersions {
+		if existing.SchemeID == schemeID && existing.Version == version {
+			existing = copySchemeVersion(existing)
+			return &existing, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// sortedSchemes returns copies of the schemes accepted by keep, ordered like the gorm repository
+func sortedSchemes(state *memoryState, keep func(models.Scheme) bool) []models.Scheme {
+	schemes := []models.Scheme{}
+	for _, scheme := range state.schemes {
+		if keep(scheme) {
+			schemes = append(schemes, copyScheme(scheme))
+		}
+	}
+
+	sort.Slice(schemes, func(i, j int) bool {
+		if !schemes[i].CreatedAt.Equal(schemes[j].CreatedAt) {
+			return schemes[i].CreatedAt.Before(schemes[j].CreatedAt)
+		}
+		return schemes[i].ID < schemes[j].ID
+	})
+
+	return schemes
+}
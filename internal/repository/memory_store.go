@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"gorm.io/gorm"
+)
+
+type eligibilityKey struct {
+	applicantID string
+	schemeID    string
+}
+
+type idempotencyKey struct {
+	actor string
+	key   string
+}
+
+// memoryState holds every record of a MemoryStore. Records are stored and
+// returned by value so callers never share slices with the store.
+// Soft deleted records are moved to the deleted maps until they are restored or purged.
+type memoryState struct {
+	applicants          map[string]models.Applicant
+	schemes             map[string]models.Scheme
+	applications        map[string]models.Application
+	deletedApplicants   map[string]models.Applicant
+	deletedSchemes      map[string]models.Scheme
+	deletedApplications map[string]models.Application
+	schemeVersions      []models.SchemeVersion
+	statusChanges       []models.ApplicationStatusChange
+	eligibility         map[eligibilityKey]models.ApplicantSchemeEligibility
+	auditLog            []models.AuditEntry
+	ledger              []models.LedgerEntry
+	outbox              []models.OutboxEvent
+	webhooks            map[string]models.WebhookSubscription
+	webhookDeliveries   []models.WebhookDelivery
+	idempotency         map[idempotencyKey]models.IdempotencyRecord
+}
+
+func newMemoryState() *memoryState {
+	return &memoryState{
+		applicants:          map[string]models.Applicant{},
+		schemes:             map[string]models.Scheme{},
+		applications:        map[string]models.Application{},
+		deletedApplicants:   map[string]models.Applicant{},
+		deletedSchemes:      map[string]models.Scheme{},
+		deletedApplications: map[string]models.Application{},
+		eligibility:         map[eligibilityKey]models.ApplicantSchemeEligibility{},
+		webhooks:            map[string]models.WebhookSubscription{},
+		idempotency:         map[idempotencyKey]models.IdempotencyRecord{},
+	}
+}
+
+func (s *memoryState) clone() *memoryState {
+	clone := newMemoryState()
+	for id, applicant := range s.applicants {
+		clone.applicants[id] = copyApplicant(applicant)
+	}
+	for id, scheme := range s.schemes {
+		clone.schemes[id] = copyScheme(scheme)
+	}
+	for id, application := range s.applications {
+		clone.applications[id] = application
+	}
+	for id, applicant := range s.deletedApplicants {
+		clone.deletedApplicants[id] = copyApplicant(applicant)
+	}
+	for id, scheme := range s.deletedSchemes {
+		clone.deletedSchemes[id] = copyScheme(scheme)
+	}
+	for id, application := range s.deletedApplications {
+		clone.deletedApplications[id] = application
+	}
+	for _, version := range s.schemeVersions {
+		clone.schemeVersions = append(clone.schemeVersions, copySchemeVersion(version))
+	}
+	clone.statusChanges = append(clone.statusChanges, s.statusChanges...)
+	for key, row := range s.eligibility {
+		clone.eligibility[key] = row
+	}
+	clone.auditLog = append(clone.auditLog, s.auditLog...)
+	clone.ledger = append(clone.ledger, s.ledger...)
+	clone.outbox = append(clone.outbox, s.outbox...)
+	for id, subscription := range s.webhooks {
+		clone.webhooks[id] = copyWebhookSubscription(subscription)
+	}
+	clone.webhookDeliveries = append(clone.webhookDeliveries, s.webhookDeliveries...)
+	for key, record := range s.idempotency {
+		clone.idempotency[key] = record
+	}
+	return clone
+}
+
+// MemoryStore is an in-memory Store for tests and local experiments.
+// It is safe for concurrent use; transactions are serialized and work on a
+// copy of the data that replaces the original only when they succeed.
+type MemoryStore struct {
+	mu    *sync.Mutex
+	state *memoryState
+	inTx  bool
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{mu: &sync.Mutex{}, state: newMemoryState()}
+}
+
+// lock acquires the store lock unless the store is already inside a transaction
+func (s *MemoryStore) lock() func() {
+	if s.inTx {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+func (s *MemoryStore) Applicants() ApplicantRepository {
+	return &memoryApplicantRepository{store: s}
+}
+
+func (s *MemoryStore) Schemes() SchemeRepository {
+	return &memorySchemeRepository{store: s}
+}
+
+func (s *MemoryStore) Applications() ApplicationRepository {
+	return &memoryApplicationRepository{store: s}
+}
+
+func (s *MemoryStore) Eligibility() EligibilityRepository {
+	return &memoryEligibilityRepository{store: s}
+}
+
+func (s *MemoryStore) Audit() AuditRepository {
+	return &memoryAuditRepository{store: s}
+}
+
+func (s *MemoryStore) Ledger() LedgerRepository {
+	return &memoryLedgerRepository{store: s}
+}
+
+func (s *MemoryStore) Outbox() OutboxRepository {
+	return &memoryOutboxRepository{store: s}
+}
+
+func (s *MemoryStore) Webhooks() WebhookRepository {
+	return &memoryWebhookRepository{store: s}
+}
+
+func (s *MemoryStore) Idempotency() IdempotencyRepository {
+	return &memoryIdempotencyRepository{store: s}
+}
+
+func (s *MemoryStore) Transaction(fn func(tx Store) error) error {
+	if s.inTx {
+		return fn(s)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &MemoryStore{mu: s.mu, state: s.state.clone(), inTx: true}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	s.state = tx.state
+	return nil
+}
+
+func copyApplicant(applicant models.Applicant) models.Applicant {
+	applicant.Household = append([]models.HouseholdMember(nil), applicant.Household...)
+	return applicant
+}
+
+func copyScheme(scheme models.Scheme) models.Scheme {
+	scheme.Benefits = copyBenefits(scheme.Benefits)
+	return scheme
+}
+
+func copySchemeVersion(version models.SchemeVersion) models.SchemeVersion {
+	version.Benefits = copyBenefits(version.Benefits)
+	return version
+}
+
+func copyWebhookSubscription(subscription models.WebhookSubscription) models.WebhookSubscription {
+	subscription.EventTypes = append(models.EventTypeList(nil), subscription.EventTypes...)
+	return subscription
+}
+
+func copyBenefits(benefits []models.Benefit) []models.Benefit {
+	copied := append([]models.Benefit(nil), benefits...)
+	for i, benefit := range copied {
+		if benefit.Recurrence != nil {
+			recurrence := *benefit.Recurrence
+			copied[i].Recurrence = &recurrence
+		}
+		if benefit.Formula != nil {
+			formula := models.AmountFormula{Rules: append([]models.AmountRule(nil), benefit.Formula.Rules...)}
+			copied[i].Formula = &formula
+		}
+	}
+	return copied
+}
+
+func deletedNow() gorm.DeletedAt {
+	return gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+// deletedBefore returns the records of a deleted map deleted before the given time, ordered like the gorm repositories
+func deletedBefore[T any](records map[string]T, before time.Time, deletedAt func(T) gorm.DeletedAt, idOf func(T) string, copyOf func(T) T) []T {
+	result := []T{}
+	for _, record := range records {
+		if deletedAt(record).Time.Before(before) {
+			result = append(result, copyOf(record))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := deletedAt(result[i]).Time, deletedAt(result[j]).Time
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return idOf(result[i]) < idOf(result[j])
+	})
+
+	return result
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+type memoryWebhookRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryWebhookRepository) CreateSubscription(subscription *models.WebhookSubscription) error {
+	defer r.store.lock()()
+
+	r.store.state.webhooks[subscription.ID] = copyWebhookSubscription(*subscription)
+	return nil
+}
+
+func (r *memoryWebhookRepository) FindSubscription(id string) (*models.WebhookSubscription, error) {
+	defer r.store.lock()()
+
+	subscription, ok := r.store.state.webhooks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	subscription = copyWebhookSubscription(subscription)
+	return &subscription, nil
+}
+
+func (r *memoryWebhookRepository) ListSubscriptions() ([]models.WebhookSubscription, error) {
+	defer r.store.lock()()
+
+	subscriptions := []models.WebhookSubscription{}
+	for _, subscription := range r.store.state.webhooks {
+		subscriptions = append(subscriptions, copyWebhookSubscription(subscription))
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if !subscriptions[i].CreatedAt.Equal(subscriptions[j].CreatedAt) {
+			return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+		}
+		return subscriptions[i].ID < subscriptions[j].ID
+	})
+	return subscriptions, nil
+}
+
+func (r *memoryWebhookRepository) UpdateSubscription(subscription *models.WebhookSubscription) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.webhooks[subscription.ID]; !ok {
+		return ErrNotFound
+	}
+	r.store.state.webhooks[subscription.ID] = copyWebhookSubscription(*subscription)
+	return nil
+}
+
+func (r *memoryWebhookRepository) DeleteSubscription(id string) error {
+	defer r.store.lock()()
+
+	if _, ok := r.store.state.webhooks[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.store.state.webhooks, id)
+	return nil
+}
+
+func (r *memoryWebhookRepository) AddDelivery(delivery *models.WebhookDelivery) (bool, error) {
+	defer r.store.lock()()
+
+	for _, existing := range r.store.state.webhookDeliveries {
+		if existing.SubscriptionID == delivery.SubscriptionID && existing.EventID == delivery.EventID {
+			return false, nil
+		}
+	}
+
+	r.store.state.webhookDeliveries = append(r.store.state.webhookDeliveries, *delivery)
+	return true, nil
+}
+
+func (r *memoryWebhookRepository) FindDelivery(id string) (*models.WebhookDelivery, error) {
+	defer r.store.lock()()
+
+	for _, delivery := range r.store.state.webhookDeliveries {
+		if delivery.ID == id {
+			return &delivery, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryWebhookRepository) ListDeliveryPage(filter dto.WebhookDeliveryFilter, opts dto.ListOptions) ([]models.WebhookDelivery, string, error) {
+	defer r.store.lock()()
+
+	deliveries := []models.WebhookDelivery{}
+	for _, delivery := range r.store.state.webhookDeliveries {
+		if filter.SubscriptionID != "" && delivery.SubscriptionID != filter.SubscriptionID {
+			continue
+		}
+		if filter.Status != "" && delivery.Status != filter.Status {
+			continue
+		}
+		if filter.EventType != "" && delivery.EventType != filter.EventType {
+			continue
+		}
+		if !inCreatedRange(delivery.CreatedAt, filter.Created) {
+			continue
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return memoryPage(deliveries, opts, data.WEBHOOK_DELIVERY_SORT_FIELDS, webhookDeliverySortValue, func(delivery models.WebhookDelivery) string {
+		return delivery.ID
+	})
+}
+
+// ListDueDeliveries relies on deliveries being appended in creation order
+func (r *memoryWebhookRepository) ListDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	defer r.store.lock()()
+
+	deliveries := []models.WebhookDelivery{}
+	for _, delivery := range r.store.state.webhookDeliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		if deliveryDue(delivery, now) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *memoryWebhookRepository) ClaimDelivery(id string, now, until time.Time) (bool, error) {
+	defer r.store.lock()()
+
+	for i, delivery := range r.store.state.webhookDeliveries {
+		if delivery.ID == id {
+			if !deliveryDue(delivery, now) {
+				return false, nil
+			}
+			r.store.state.webhookDeliveries[i].LockedUntil = &until
+			return true, nil
+		}
+	}
+	return false, ErrNotFound
+}
+
+func (r *memoryWebhookRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	defer r.store.lock()()
+
+	for i, existing := range r.store.state.webhookDeliveries {
+		if existing.ID == delivery.ID {
+			delivery.LockedUntil = nil
+			r.store.state.webhookDeliveries[i] = *delivery
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func deliveryDue(delivery models.WebhookDelivery, now time.Time) bool {
+	return delivery.Status == data.WEBHOOK_DELIVERY_PENDING && !delivery.NextAttemptAt.After(now) &&
+		(delivery.LockedUntil == nil || !delivery.LockedUntil.After(now))
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+var ErrNotFound = errors.New("record not found")
+
+var ErrInvalidOffset = errors.New("offset must not be negative")
+
+// Store groups the repositories used by the services.
+// Transaction runs fn against a Store whose repositories share a single transaction;
+// the changes are discarded when fn returns an error.
+type Store interface {
+	Applicants() ApplicantRepository
+	Schemes() SchemeRepository
+	Applications() ApplicationRepository
+	Eligibility() EligibilityRepository
+	Audit() AuditRepository
+	Ledger() LedgerRepository
+	Outbox() OutboxRepository
+	Webhooks() WebhookRepository
+	Idempotency() IdempotencyRepository
+	Transaction(fn func(tx Store) error) error
+}
+
+// ApplicantRepository persists applicants together with their household members.
+// Delete is a soft delete: deleted applicants are hidden from every other query
+// until they are restored or purged.
+type ApplicantRepository interface {
+	Create(applicant *models.Applicant) error
+	FindByID(id string) (*models.Applicant, error)
+	FindByIDs(ids []string) ([]models.Applicant, error)
+	// SearchNames returns the applicant and household member names that match query,
+	// limited to the limit best matching applicants and ordered by their best score
+	SearchNames(query string, limit int) ([]NameMatch, error)
+	// ListPage returns one page of the applicants matching filter and the cursor of the next page
+	ListPage(filter dto.ApplicantFilter, opts dto.ListOptions) ([]models.Applicant, string, error)
+	// Update saves the applicant's fields and replaces their household
+	Update(applicant *models.Applicant) error
+	Delete(id string) error
+	FindDeleted(id string) (*models.Applicant, error)
+	Restore(id string) error
+	// ListDeleted returns the applicants deleted before the given time
+	ListDeleted(before time.Time) ([]models.Applicant, error)
+	// Purge permanently removes a deleted applicant and their household
+	Purge(id string) error
+	ForEachBatch(batchSize int, fn func(applicants []models.Applicant) error) error
+}
+
+// SchemeRepository persists schemes together with their benefits. Delete is a soft delete.
+type SchemeRepository interface {
+	Create(scheme *models.Scheme) error
+	FindByID(id string) (*models.Scheme, error)
+	List() ([]models.Scheme, error)
+	// ListPage returns one page of the schemes matching filter and the cursor of the next page
+	ListPage(filter dto.SchemeFilter, opts dto.ListOptions) ([]models.Scheme, string, error)
+	// Update saves the scheme's fields and replaces its benefits
+	Update(scheme *models.Scheme) error
+	Delete(id string) error
+	FindDeleted(id string) (*models.Scheme, error)
+	Restore(id string) error
+	// ListDeleted returns the schemes deleted before the given time
+	ListDeleted(before time.Time) ([]models.Scheme, error)
+	// Purge permanently removes a deleted scheme, its benefits and its versions
+	Purge(id string) error
+	// ReserveCapacity adds amount and one recipient to the scheme's reserved capacity in a single
+	// conditional update, so concurrent reservations cannot exceed the caps. It reports whether it did.
+	ReserveCapacity(id string, amount models.Money) (bool, error)
+	// ReleaseCapacity gives back a reservation, also for deleted schemes
+	ReleaseCapacity(id string, amount models.Money) error
+	// AddVersion stores a snapshot of the scheme's terms. Versions are never changed.
+	AddVersion(version *models.SchemeVersion) error
+	ListVersions(schemeID string) ([]models.SchemeVersion, error)
+	FindVersion(schemeID string, version int) (*models.SchemeVersion, error)
+}
+
+// ApplicationRepository persists applications and their status history. Delete and
+// DeleteByApplicantID are soft deletes that keep the history.
+type ApplicationRepository interface {
+	Create(application *models.Application) error
+	FindByID(id string) (*models.Application, error)
+	FindByApplicantAndScheme(applicantID, schemeID string) (*models.Application, error)
+	ListByApplicant(applicantID string) ([]models.Application, error)
+	// ListPage returns one page of the applications matching filter and the cursor of the next page
+	ListPage(filter dto.ApplicationFilter, opts dto.ListOptions) ([]models.Application, string, error)
+	Update(application *models.Application) error
+	// UpdateStatus changes the status, reserved amount and approval date only if the status is still fromStatus
+	// and reports whether it did
+	UpdateStatus(application *models.Application, fromStatus string) (bool, error)
+	Delete(id string) error
+	DeleteByApplicantID(applicantID string) error
+	FindDeleted(id string) (*models.Application, error)
+	Restore(id string) error
+	// RestoreByApplicantID restores the applicant's applications deleted at or after deletedSince
+	RestoreByApplicantID(applicantID string, deletedSince time.Time) ([]models.Application, error)
+	// ListDeleted returns the applications deleted before the given time
+	ListDeleted(before time.Time) ([]models.Application, error)
+	// HasScheme reports whether any application, deleted or not, refers to the scheme
+	HasScheme(schemeID string) (bool, error)
+	// Purge permanently removes a deleted application and its status history
+	Purge(id string) error
+	// PurgeByApplicantID permanently removes every application of the applicant and returns them
+	PurgeByApplicantID(applicantID string) ([]models.Application, error)
+	AddStatusChange(change *models.ApplicationStatusChange) error
+	ListStatusChanges(applicationID string) ([]models.ApplicationStatusChange, error)
+}
+
+// EligibilityRepository persists the applicant x scheme eligibility matrix
+type EligibilityRepository interface {
+	Upsert(rows []models.ApplicantSchemeEligibility) error
+	ListByApplicant(applicantID string) ([]models.ApplicantSchemeEligibility, error)
+	DeleteByApplicant(applicantID string) error
+	DeleteByScheme(schemeID string) error
+	// ForEachApplicantToEvaluate visits applicants without a row for the scheme and,
+	// when outdated is true, those whose row was evaluated on a date other than referenceDate
+	ForEachApplicantToEvaluate(schemeID, referenceDate string, outdated bool, batchSize int, fn func(applicants []models.Applicant) error) error
+	ListEligibleSchemes(applicantID string) ([]models.Scheme, error)
+	// ListEligibleApplicants fails with ErrInvalidOffset for a negative offset
+	ListEligibleApplicants(schemeID string, excludeApplied bool, offset, limit int) ([]models.Applicant, int64, error)
+}
+
+// AuditRepository appends to the audit log. Entries cannot be changed or removed.
+type AuditRepository interface {
+	Append(entry *models.AuditEntry) error
+	// ListPage returns one page of the entries matching filter and the cursor of the next page
+	ListPage(filter dto.AuditFilter, opts dto.ListOptions) ([]models.AuditEntry, string, error)
+}
+
+// LedgerRepository appends disbursement ledger entries. Entries are never changed or deleted, and
+// Append fails when the disbursement already has an entry with the same sequence.
+type LedgerRepository interface {
+	Append(entry *models.LedgerEntry) error
+	// ListByDisbursement returns the disbursement's entries ordered by sequence
+	ListByDisbursement(disbursementID string) ([]models.LedgerEntry, error)
+	// List returns the matching entries ordered by creation, then sequence
+	List(filter dto.LedgerFilter) ([]models.LedgerEntry, error)
+}
+
+// OutboxRepository stores domain events until they are dispatched. Several dispatchers may run at
+// once: an event is only delivered by the dispatcher whose Claim succeeded, until its lease expires.
+type OutboxRepository interface {
+	Append(event *models.OutboxEvent) error
+	// ListPending returns up to limit undispatched events that are due for an attempt on now and not
+	// claimed by another dispatcher, oldest first
+	ListPending(now time.Time, limit int) ([]models.OutboxEvent, error)
+	// Claim leases a due event until the given time, and reports false when another dispatcher holds
+	// or already attempted it
+	Claim(id string, now, until time.Time) (bool, error)
+	MarkDispatched(id string, at time.Time) error
+	// MarkFailed records a failed attempt and when to try again
+	MarkFailed(id, lastError string, nextAttemptAt time.Time) error
+	// DeleteDispatched removes the events dispatched before the given time
+	DeleteDispatched(before time.Time) (int64, error)
+}
+
+// WebhookRepository persists webhook subscriptions and the deliveries of events to them.
+// Deliveries are claimed like outbox events, so several workers can deliver at once.
+type WebhookRepository interface {
+	CreateSubscription(subscription *models.WebhookSubscription) error
+	FindSubscription(id string) (*models.WebhookSubscription, error)
+	// ListSubscriptions returns every subscription, oldest first
+	ListSubscriptions() ([]models.WebhookSubscription, error)
+	UpdateSubscription(subscription *models.WebhookSubscription) error
+	// DeleteSubscription removes the subscription, its deliveries are kept
+	DeleteSubscription(id string) error
+	// AddDelivery queues a delivery and reports false when the subscription already has one for the event
+	AddDelivery(delivery *models.WebhookDelivery) (bool, error)
+	FindDelivery(id string) (*models.WebhookDelivery, error)
+	// ListDeliveryPage returns one page of the deliveries matching filter and the cursor of the next page
+	ListDeliveryPage(filter dto.WebhookDeliveryFilter, opts dto.ListOptions) ([]models.WebhookDelivery, string, error)
+	// ListDueDeliveries returns up to limit pending deliveries due on now that are not claimed, oldest first
+	ListDueDeliveries(now time.Time, limit int) ([]models.WebhookDelivery, error)
+	// ClaimDelivery leases a due pending delivery until the given time, and reports false when it was
+	// claimed or attempted by another worker
+	ClaimDelivery(id string, now, until time.Time) (bool, error)
+	// UpdateDelivery saves the outcome of an attempt or a redelivery and releases the claim
+	UpdateDelivery(delivery *models.WebhookDelivery) error
+}
+
+// IdempotencyRepository keeps the responses to requests sent with an Idempotency-Key, per actor.
+// Of concurrent requests with the same key only the one whose Create or Claim succeeded runs.
+type IdempotencyRepository interface {
+	// Create stores a new key and reports false when the actor already has it
+	Create(record *models.IdempotencyRecord) (bool, error)
+	Find(actor, key string) (*models.IdempotencyRecord, error)
+	// Claim replaces a key created before expiredBefore, or the key of the same request whose lease
+	// expired on now, and reports false when neither is the case
+	Claim(record *models.IdempotencyRecord, now, expiredBefore time.Time) (bool, error)
+	// Complete stores the response of the request holding the key and releases it
+	Complete(record *models.IdempotencyRecord) error
+	// Delete releases a key so the request can be sent again
+	Delete(actor, key string) error
+	// DeleteExpired removes the keys created before the given time
+	DeleteExpired(before time.Time) (int64, error)
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+)
+
+// NameMatch is an applicant or household member name that matched a search.
+// MemberID is empty when the applicant's own name matched.
+type NameMatch struct {
+	ApplicantID string
+	MemberID    string
+	Name        string
+	Score       float64
+}
+
+// rankNameMatches scores names in Go for databases without pg_trgm. It keeps the
+// matches of the limit best matching applicants, ordered like the Postgres search.
+func rankNameMatches(query string, candidates []NameMatch, limit int) []NameMatch {
+	best := map[string]float64{}
+	var matches []NameMatch
+	for _, candidate := range candidates {
+		candidate.Score = utils.NameMatchScore(query, candidate.Name)
+		if candidate.Score == 0 {
+			continue
+		}
+
+		matches = append(matches, candidate)
+		if candidate.Score > best[candidate.ApplicantID] {
+			best[candidate.ApplicantID] = candidate.Score
+		}
+	}
+
+	applicantIDs := make([]string, 0, len(best))
+	for applicantID := range best {
+		applicantIDs = append(applicantIDs, applicantID)
+	}
+	sort.Slice(applicantIDs, func(i, j int) bool {
+		if best[applicantIDs[i]] != best[applicantIDs[j]] {
+			return best[applicantIDs[i]] > best[applicantIDs[j]]
+		}
+		return applicantIDs[i] < applicantIDs[j]
+	})
+
+	if len(applicantIDs) > limit {
+		for _, applicantID := range applicantIDs[limit:] {
+			delete(best, applicantID)
+		}
+	}
+
+	kept := []NameMatch{}
+	for _, match := range matches {
+		if _, ok := best[match.ApplicantID]; ok {
+			kept = append(kept, match)
+		}
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		a, b := kept[i], kept[j]
+		if best[a.ApplicantID] != best[b.ApplicantID] {
+			return best[a.ApplicantID] > best[b.ApplicantID]
+		}
+		if a.ApplicantID != b.ApplicantID {
+			return a.ApplicantID < b.ApplicantID
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.MemberID < b.MemberID
+	})
+
+	return kept
+}
@@ -1,41 +1,114 @@
 package routes
 
 import (
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/handlers"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/middleware"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(router *gin.Engine, applicantHandler *handlers.ApplicantHandler, schemeHandler *handlers.SchemeHandler, applicationHandler *handlers.ApplicationHandler) {
-	api := router.Group("/api")
+func SetupRoutes(router *gin.Engine, authenticator *middleware.Authenticator, idempotencyService *services.IdempotencyService, applicantHandler *handlers.ApplicantHandler, schemeHandler *handlers.SchemeHandler, applicationHandler *handlers.ApplicationHandler, auditHandler *handlers.AuditHandler, purgeHandler *handlers.PurgeHandler, ledgerHandler *handlers.LedgerHandler, webhookHandler *handlers.WebhookHandler) {
+	api := router.Group("/api", authenticator.Authenticate())
+
+	// Every role can read, auditors only read
+	readers := middleware.RequireRoles(data.ROLE_ADMIN, data.ROLE_CASEWORKER, data.ROLE_AUDITOR)
+	caseworkers := middleware.RequireRoles(data.ROLE_CASEWORKER)
+	admins := middleware.RequireRoles(data.ROLE_ADMIN)
+	auditors := middleware.RequireRoles(data.ROLE_ADMIN, data.ROLE_AUDITOR)
+
+	// POST requests with an Idempotency-Key header can be retried safely, after the role check
+	// so only requests that reach a handler store their response
+	idempotent := middleware.Idempotency(idempotencyService)
 
 	// Applicant
 	applicantRoutes := api.Group("/applicants")
 	{
-		applicantRoutes.POST("/", applicantHandler.CreateApplicant)
-		applicantRoutes.GET("/", applicantHandler.GetAllApplicants)
-		applicantRoutes.GET("/:id", applicantHandler.GetApplicant)
-		applicantRoutes.PUT("/:id", applicantHandler.UpdateApplicant)
-		applicantRoutes.DELETE("/:id", applicantHandler.DeleteApplicant)
+		read := applicantRoutes.Group("", readers)
+		read.GET("/", applicantHandler.GetAllApplicants)
+		read.GET("/search", applicantHandler.SearchApplicants)
+		read.GET("/:id", applicantHandler.GetApplicant)
+		read.GET("/:id/balance", ledgerHandler.GetApplicantBalance)
+		read.GET("/:id/installments", ledgerHandler.GetApplicantInstallments)
+
+		write := applicantRoutes.Group("", caseworkers, idempotent)
+		write.POST("/", applicantHandler.CreateApplicant)
+		write.PUT("/:id", applicantHandler.UpdateApplicant)
+		write.DELETE("/:id", applicantHandler.DeleteApplicant)
+		write.POST("/:id/restore", applicantHandler.RestoreApplicant)
 	}
 
 	// Scheme
 	schemeRoutes := api.Group("/schemes")
 	{
-		schemeRoutes.POST("/", schemeHandler.CreateScheme)
-		schemeRoutes.GET("/", schemeHandler.GetAllSchemes)
-		schemeRoutes.GET("/:id", schemeHandler.GetSchemeByID)
-		schemeRoutes.PUT("/:id", schemeHandler.UpdateScheme)
-		schemeRoutes.DELETE("/:id", schemeHandler.DeleteScheme)
-		schemeRoutes.GET("/eligible/:applicantID", schemeHandler.GetEligibleSchemes)
+		read := schemeRoutes.Group("", readers)
+		read.GET("/", schemeHandler.GetAllSchemes)
+		read.GET("/:id", schemeHandler.GetSchemeByID)
+		read.GET("/:id/versions", schemeHandler.GetSchemeVersions)
+		read.GET("/:id/versions/:version", schemeHandler.GetSchemeVersion)
+		read.GET("/:id/eligible-applicants", schemeHandler.GetEligibleApplicants)
+		read.GET("/:id/balance", ledgerHandler.GetSchemeBalance)
+		read.GET("/eligible/:applicantID", schemeHandler.GetEligibleSchemes)
+		read.GET("/eligible/:applicantID/explain", schemeHandler.ExplainEligibility)
+
+		write := schemeRoutes.Group("", admins, idempotent)
+		write.POST("/", schemeHandler.CreateScheme)
+		write.PUT("/:id", schemeHandler.UpdateScheme)
+		write.DELETE("/:id", schemeHandler.DeleteScheme)
+		write.POST("/:id/restore", schemeHandler.RestoreScheme)
 	}
 
 	// Applications
 	applicationRoutes := api.Group("/applications")
 	{
-		applicationRoutes.POST("/", applicationHandler.RegisterApplication)
-		applicationRoutes.GET("/", applicationHandler.GetApplications)
-		applicationRoutes.PUT("/:id", applicationHandler.UpdateApplication)
-		applicationRoutes.DELETE("/:id", applicationHandler.DeleteApplication)
-		applicationRoutes.DELETE("/applicant/:applicant_id", applicationHandler.DeleteApplicationByApplicantID)
+		read := applicationRoutes.Group("", readers)
+		read.GET("/", applicationHandler.GetApplications)
+		read.GET("/:id/history", applicationHandler.GetApplicationHistory)
+		read.GET("/:id/disbursements", ledgerHandler.GetApplicationDisbursements)
+
+		write := applicationRoutes.Group("", caseworkers, idempotent)
+		write.POST("/", applicationHandler.RegisterApplication)
+		write.PUT("/:id", applicationHandler.UpdateApplication)
+		write.DELETE("/:id", applicationHandler.DeleteApplication)
+		write.POST("/:id/restore", applicationHandler.RestoreApplication)
+		write.POST("/:id/review", applicationHandler.ReviewApplication)
+		write.POST("/:id/approve", applicationHandler.ApproveApplication)
+		write.POST("/:id/reject", applicationHandler.RejectApplication)
+		write.POST("/:id/withdraw", applicationHandler.WithdrawApplication)
+		write.DELETE("/applicant/:applicant_id", applicationHandler.DeleteApplicationByApplicantID)
+		write.POST("/:id/disbursements", ledgerHandler.ScheduleDisbursement)
+	}
+
+	// Disbursements
+	disbursementRoutes := api.Group("/disbursements")
+	{
+		read := disbursementRoutes.Group("", readers)
+		read.GET("/:id", ledgerHandler.GetDisbursement)
+
+		write := disbursementRoutes.Group("", caseworkers, idempotent)
+		write.POST("/generate", ledgerHandler.GenerateDuePayouts)
+		write.POST("/:id/pay", ledgerHandler.PayDisbursement)
+		write.POST("/:id/fail", ledgerHandler.FailDisbursement)
+		write.POST("/:id/reverse", ledgerHandler.ReverseDisbursement)
 	}
+
+	// Webhooks
+	webhookRoutes := api.Group("/webhooks", admins, idempotent)
+	{
+		webhookRoutes.GET("/", webhookHandler.GetWebhooks)
+		webhookRoutes.POST("/", webhookHandler.CreateWebhook)
+		webhookRoutes.GET("/:id", webhookHandler.GetWebhook)
+		webhookRoutes.PUT("/:id", webhookHandler.UpdateWebhook)
+		webhookRoutes.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhookRoutes.GET("/deliveries", webhookHandler.GetDeliveries)
+		webhookRoutes.GET("/deliveries/:id", webhookHandler.GetDelivery)
+		webhookRoutes.POST("/deliveries/:id/redeliver", webhookHandler.RedeliverDelivery)
+		webhookRoutes.POST("/deliveries/deliver", webhookHandler.DeliverDue)
+	}
+
+	// Audit
+	api.GET("/audit", auditors, auditHandler.GetAuditEntries)
+
+	// Permanently remove records deleted before the retention period
+	api.POST("/admin/purge", admins, idempotent, purgeHandler.PurgeDeleted)
 }
@@ -4,48 +4,46 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
-	"gorm.io/gorm"
 )
 
+const maxSearchQueryLength = 100
+
 type ApplicantService struct {
-	DB *gorm.DB
+	Store repository.Store
 }
 
-func NewApplicantService(db *gorm.DB) *ApplicantService {
-	return &ApplicantService{DB: db}
+func NewApplicantService(store repository.Store) *ApplicantService {
+	return &ApplicantService{Store: store}
 }
 
 // CREATE Applicant with Household Members
-func (s *ApplicantService) RegisterApplicantWithHousehold(data *models.ApplicantWithHousehold) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
-
-	if err := utils.ValidateApplicant(data.Name, data.EmploymentStatus, data.Sex, data.DateOfBirth); err != nil {
+func (s *ApplicantService) RegisterApplicantWithHousehold(applicantData *models.ApplicantWithHousehold, actor string) error {
+	if err := utils.ValidateApplicant(applicantData.Name, applicantData.EmploymentStatus, applicantData.Sex, applicantData.DateOfBirth); err != nil {
 		return err
 	}
 
 	applicant := models.Applicant{
 		ID:               utils.GenerateUUID(),
-		Name:             data.Name,
-		EmploymentStatus: data.EmploymentStatus,
-		Sex:              data.Sex,
-		DateOfBirth:      data.DateOfBirth,
+		Name:             applicantData.Name,
+		EmploymentStatus: applicantData.EmploymentStatus,
+		Sex:              applicantData.Sex,
+		DateOfBirth:      applicantData.DateOfBirth,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
 	}
 
-	householdMembers := make([]models.HouseholdMember, len(data.Household))
+	householdMembers := make([]models.HouseholdMember, len(applicantData.Household))
 
-	for i, member := range data.Household {
+	for i, member := range applicantData.Household {
 		if err := utils.ValidateApplicant(member.Name, member.EmploymentStatus, member.Sex, member.DateOfBirth); err != nil {
-			tx.Rollback()
 			return fmt.Errorf("household member validation failed for '%s': %v", member.Name, err)
 		}
 
@@ -69,159 +67,146 @@ func (s *ApplicantService) RegisterApplicantWithHousehold(data *models.Applicant
 		}
 	}
 
-	if err := tx.Create(&applicant).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+	applicant.Household = householdMembers
 
-	if len(householdMembers) > 0 {
-		if err := tx.Create(&householdMembers).Error; err != nil {
-			tx.Rollback()
+	return s.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Applicants().Create(&applicant); err != nil {
 			return err
 		}
-	}
 
-	return tx.Commit().Error
+		if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICANT, applicant.ID, data.AUDIT_ACTION_CREATE, nil, dto.ApplicantWithHouseholdFromModel(applicant)); err != nil {
+			return err
+		}
+
+		if err := refreshApplicantEligibility(tx, applicant, time.Now()); err != nil {
+			return errors.New("failed to compute applicant eligibility")
+		}
+
+		return nil
+	})
 }
 
 // RETRIEVE All Applicant with Household Members
-func (s *ApplicantService) GetApplicants(ctx context.Context) ([]dto.ApplicantWithHousehold, error) {
+func (s *ApplicantService) GetApplicants(ctx context.Context, filter dto.ApplicantFilter, opts dto.ListOptions) ([]dto.ApplicantWithHousehold, *dto.CursorPagination, error) {
+	if err := utils.ValidateSortField(opts.Sort, data.APPLICANT_SORT_FIELDS); err != nil {
+		return nil, nil, err
+	}
 
-	var applicants []models.Applicant
-	if err := s.DB.Preload("Household").Find(&applicants).Error; err != nil {
-		return nil, err
+	applicants, next, err := s.Store.Applicants().ListPage(filter, opts)
+	if err != nil {
+		return nil, nil, listError(err, "failed to retrieve applicants")
 	}
 
 	output := make([]dto.ApplicantWithHousehold, len(applicants))
 	for i, applicant := range applicants {
-		householdDTO := make([]dto.HouseholdMember, len(applicant.Household))
-		for j, member := range applicant.Household {
-			householdDTO[j] = dto.HouseholdMember{
-				ID:               member.ID,
-				Name:             member.Name,
-				EmploymentStatus: member.EmploymentStatus,
-				Sex:              member.Sex,
-				DateOfBirth:      member.DateOfBirth,
-				Relation:         member.Relation,
-			}
-		}
-
-		output[i] = dto.ApplicantWithHousehold{
-			Applicant: dto.Applicant{
-				ID:               applicant.ID,
-				Name:             applicant.Name,
-				EmploymentStatus: applicant.EmploymentStatus,
-				Sex:              applicant.Sex,
-				DateOfBirth:      applicant.DateOfBirth,
-			},
-			Household: householdDTO,
-		}
+		output[i] = dto.ApplicantWithHouseholdFromModel(applicant)
 	}
 
-	return output, nil
+	return output, &dto.CursorPagination{Limit: opts.Limit, NextCursor: next}, nil
 }
 
 // RETRIEVE Applicant with Household Members by Applicant ID
 func (s *ApplicantService) GetApplicantWithID(id string) (*dto.ApplicantWithHousehold, error) {
-	var applicant models.Applicant
-	var household []models.HouseholdMember
-
-	if err := s.DB.First(&applicant, "id = ?", id).Error; err != nil {
+	applicant, err := s.Store.Applicants().FindByID(id)
+	if err != nil {
 		return nil, errors.New("applicant not found")
 	}
 
-	if err := s.DB.Find(&household, "applicant_id = ?", id).Error; err != nil {
-		return nil, errors.New("failed to retrieve household members")
+	output := dto.ApplicantWithHouseholdFromModel(*applicant)
+	return &output, nil
+}
+
+// RETRIEVE Applicants by Name, including Household Member Names
+func (s *ApplicantService) SearchApplicants(query string, limit int) ([]dto.ApplicantSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("search query cannot be empty")
 	}
 
-	householdDTO := make([]dto.HouseholdMember, len(household))
-	for i, member := range household {
-		householdDTO[i] = dto.HouseholdMember{
-			ID:               member.ID,
-			Name:             member.Name,
-			EmploymentStatus: member.EmploymentStatus,
-			Sex:              member.Sex,
-			DateOfBirth:      member.DateOfBirth,
-			Relation:         member.Relation,
-		}
+	if len(query) > maxSearchQueryLength {
+		return nil, fmt.Errorf("search query cannot be longer than %d characters", maxSearchQueryLength)
 	}
 
-	return &dto.ApplicantWithHousehold{
-		Applicant: dto.Applicant{
-			ID:               applicant.ID,
-			Name:             applicant.Name,
-			EmploymentStatus: applicant.EmploymentStatus,
-			Sex:              applicant.Sex,
-			DateOfBirth:      applicant.DateOfBirth,
-		},
-		Household: householdDTO,
-	}, nil
-}
+	matches, err := s.Store.Applicants().SearchNames(query, limit)
+	if err != nil {
+		return nil, errors.New("failed to search applicants")
+	}
 
-// UDPATE applicant by ID
-func (s *ApplicantService) UpdateApplicant(id string, updatedData *models.ApplicantWithHousehold) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
+	// Matches arrive grouped by applicant, best applicant first
+	var applicantIDs []string
+	resultIndex := make(map[string]int)
+	results := []dto.ApplicantSearchResult{}
+	for _, match := range matches {
+		index, ok := resultIndex[match.ApplicantID]
+		if !ok {
+			index = len(results)
+			resultIndex[match.ApplicantID] = index
+			applicantIDs = append(applicantIDs, match.ApplicantID)
+			results = append(results, dto.ApplicantSearchResult{Score: match.Score})
+		}
+
+		results[index].Matches = append(results[index].Matches, dto.NameMatch{
+			Name:              match.Name,
+			HouseholdMemberID: match.MemberID,
+			Score:             match.Score,
+		})
 	}
 
-	var applicant models.Applicant
+	applicants, err := s.Store.Applicants().FindByIDs(applicantIDs)
+	if err != nil {
+		return nil, errors.New("failed to search applicants")
+	}
 
-	if err := tx.First(&applicant, "id = ?", id).Error; err != nil {
-		tx.Rollback()
-		return errors.New("applicant not found")
+	for _, applicant := range applicants {
+		results[resultIndex[applicant.ID]].Applicant = dto.ApplicantWithHouseholdFromModel(applicant)
 	}
 
+	return results, nil
+}
+
+// UDPATE applicant by ID
+func (s *ApplicantService) UpdateApplicant(id string, updatedData *models.ApplicantWithHousehold, actor string) error {
 	if err := utils.ValidateApplicant(updatedData.Name, updatedData.EmploymentStatus, updatedData.Sex, updatedData.DateOfBirth); err != nil {
 		return err
 	}
 
-	applicant.Name = updatedData.Name
-	applicant.EmploymentStatus = updatedData.EmploymentStatus
-	applicant.Sex = updatedData.Sex
-	applicant.DateOfBirth = updatedData.DateOfBirth
-	applicant.UpdatedAt = time.Now()
+	return s.Store.Transaction(func(tx repository.Store) error {
+		applicant, err := tx.Applicants().FindByID(id)
+		if err != nil {
+			return errors.New("applicant not found")
+		}
 
-	if err := tx.Save(&applicant).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to update applicant")
-	}
+		before := dto.ApplicantWithHouseholdFromModel(*applicant)
 
-	if err := tx.Where("applicant_id = ?", applicant.ID).Delete(&models.HouseholdMember{}).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to delete old household members")
-	}
+		existingHouseholdIDs := make(map[string]bool)
+		for _, existingMember := range applicant.Household {
+			existingHouseholdIDs[existingMember.ID] = true
+		}
 
-	existingHouseholdIDs := make(map[string]bool)
-	for _, existingMember := range applicant.Household {
-		existingHouseholdIDs[existingMember.ID] = true
-	}
+		applicant.Name = updatedData.Name
+		applicant.EmploymentStatus = updatedData.EmploymentStatus
+		applicant.Sex = updatedData.Sex
+		applicant.DateOfBirth = updatedData.DateOfBirth
+		applicant.UpdatedAt = time.Now()
 
-	householdMembers := make([]models.HouseholdMember, len(updatedData.Household))
-	for i, member := range updatedData.Household {
+		householdMembers := make([]models.HouseholdMember, len(updatedData.Household))
+		for i, member := range updatedData.Household {
 
-		if err := utils.ValidateApplicant(member.Name, member.EmploymentStatus, member.Sex, member.DateOfBirth); err != nil {
-			return err
-		}
+			if err := utils.ValidateApplicant(member.Name, member.EmploymentStatus, member.Sex, member.DateOfBirth); err != nil {
+				return err
+			}
 
-		if err := utils.ValidateRelation(member.Relation); err != nil {
-			return err
-		}
+			if err := utils.ValidateRelation(member.Relation); err != nil {
+				return err
+			}
 
-		if existingHouseholdIDs[member.ID] {
-			householdMembers[i] = models.HouseholdMember{
-				ID:               member.ID,
-				Name:             member.Name,
-				EmploymentStatus: member.EmploymentStatus,
-				Sex:              member.Sex,
-				DateOfBirth:      member.DateOfBirth,
-				Relation:         member.Relation,
-				SchoolLevel:      member.SchoolLevel,
-				ApplicantID:      applicant.ID,
+			memberID := member.ID
+			if !existingHouseholdIDs[member.ID] {
+				memberID = utils.GenerateUUID()
 			}
-		} else {
+
 			householdMembers[i] = models.HouseholdMember{
-				ID:               utils.GenerateUUID(),
+				ID:               memberID,
 				Name:             member.Name,
 				EmploymentStatus: member.EmploymentStatus,
 				Sex:              member.Sex,
@@ -231,45 +216,92 @@ func (s *ApplicantService) UpdateApplicant(id string, updatedData *models.Applic
 				ApplicantID:      applicant.ID,
 			}
 		}
-	}
 
-	if len(householdMembers) > 0 {
-		if err := tx.Create(&householdMembers).Error; err != nil {
-			tx.Rollback()
-			return errors.New("failed to update household members")
+		applicant.Household = householdMembers
+
+		if err := tx.Applicants().Update(applicant); err != nil {
+			return errors.New("failed to update applicant")
+		}
+
+		if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICANT, applicant.ID, data.AUDIT_ACTION_UPDATE, before, dto.ApplicantWithHouseholdFromModel(*applicant)); err != nil {
+			return err
 		}
-	}
 
-	return tx.Commit().Error
+		if err := refreshApplicantEligibility(tx, *applicant, time.Now()); err != nil {
+			return errors.New("failed to compute applicant eligibility")
+		}
+
+		return nil
+	})
 }
 
-// DELETE Applicant By ID
-func (s *ApplicantService) DeleteApplicant(id string) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
+// DELETE Applicant By ID, the applicant and their applications can be restored until they are purged
+func (s *ApplicantService) DeleteApplicant(id, actor string) error {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		applicant, err := tx.Applicants().FindByID(id)
+		if err != nil {
+			return errors.New("applicant not found")
+		}
 
-	var applicant models.Applicant
-	if err := tx.First(&applicant, "id = ?", id).Error; err != nil {
-		tx.Rollback()
-		return errors.New("applicant not found")
-	}
+		// The applications are deleted after the applicant so restoring the applicant can find them
+		if err := tx.Applicants().Delete(id); err != nil {
+			return errors.New("failed to delete applicant")
+		}
 
-	if err := tx.Where("applicant_id = ?", id).Delete(&models.HouseholdMember{}).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to delete household members")
-	}
+		if err := deleteApplicantApplications(tx, id, actor); err != nil {
+			return err
+		}
 
-	if err := tx.Where("applicant_id = ?", id).Delete(&models.Application{}).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to delete applicant's application")
-	}
+		if err := tx.Eligibility().DeleteByApplicant(id); err != nil {
+			return errors.New("failed to delete applicant eligibility")
+		}
+
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICANT, id, data.AUDIT_ACTION_DELETE, dto.ApplicantWithHouseholdFromModel(*applicant), nil)
+	})
+}
+
+// RESTORE Deleted Applicant by ID, together with the applications deleted with them
+func (s *ApplicantService) RestoreApplicant(id, actor string) (*dto.ApplicantWithHousehold, error) {
+	var output dto.ApplicantWithHousehold
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		applicant, err := tx.Applicants().FindDeleted(id)
+		if err != nil {
+			return errors.New("deleted applicant not found")
+		}
+
+		if err := tx.Applicants().Restore(id); err != nil {
+			return errors.New("failed to restore applicant")
+		}
+
+		applications, err := tx.Applications().RestoreByApplicantID(id, applicant.DeletedAt.Time)
+		if err != nil {
+			return errors.New("failed to restore applicant's applications")
+		}
+
+		if err := refreshApplicantEligibility(tx, *applicant, time.Now()); err != nil {
+			return errors.New("failed to compute applicant eligibility")
+		}
+
+		output = dto.ApplicantWithHouseholdFromModel(*applicant)
+		if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICANT, id, data.AUDIT_ACTION_RESTORE, nil, output); err != nil {
+			return err
+		}
 
-	if err := tx.Delete(&applicant).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to delete applicant")
+		for _, application := range applications {
+			if err := restoreCapacity(tx, &application); err != nil {
+				return err
+			}
+
+			if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, application.ID, data.AUDIT_ACTION_RESTORE, nil, application); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return tx.Commit().Error
+	return &output, nil
 }
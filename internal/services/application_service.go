@@ -2,178 +2,423 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
-	"gorm.io/gorm"
 )
 
 type ApplicationService struct {
-	DB *gorm.DB
+	Store repository.Store
 }
 
-func NewApplicationService(db *gorm.DB) *ApplicationService {
-	return &ApplicationService{DB: db}
+// EligibilityError is returned when an applicant fails the criteria of the scheme they applied for
+type EligibilityError struct {
+	Failures []dto.CriterionFailure
 }
 
-// CREATE Application
-func (s *ApplicationService) RegisterApplication(applicantID, schemeID string) error {
+func (e *EligibilityError) Error() string {
+	return "applicant is not eligible for this scheme"
+}
+
+// SchemeClosedError is returned when an application is made outside the scheme's effective period or after its deadline
+type SchemeClosedError struct {
+	Reason string
+}
 
-	var applicantCount, schemeCount int64
-	s.DB.Model(&models.Applicant{}).Where("id = ?", applicantID).Count(&applicantCount)
-	s.DB.Model(&models.Scheme{}).Where("id = ?", schemeID).Count(&schemeCount)
+func (e *SchemeClosedError) Error() string {
+	return "scheme is not accepting applications: " + e.Reason
+}
+
+// CapacityError is returned when approving an application would exceed the scheme's budget or recipient limit
+type CapacityError struct {
+	Reason string
+}
 
-	if applicantCount == 0 {
+func (e *CapacityError) Error() string {
+	return "scheme has no remaining capacity: " + e.Reason
+}
+
+func NewApplicationService(store repository.Store) *ApplicationService {
+	return &ApplicationService{Store: store}
+}
+
+/* Service Functions */
+
+// CREATE Application
+func (s *ApplicationService) RegisterApplication(applicantID, schemeID, actor string, override *dto.EligibilityOverride) error {
+	applicant, err := s.Store.Applicants().FindByID(applicantID)
+	if err != nil {
 		return errors.New("applicant not found")
 	}
 
-	if schemeCount == 0 {
+	scheme, err := s.Store.Schemes().FindByID(schemeID)
+	if err != nil {
 		return errors.New("scheme not found")
 	}
 
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
-
-	var existingApplication models.Application
-	if err := tx.Where("applicant_id = ? AND scheme_id = ?", applicantID, schemeID).
-		First(&existingApplication).Error; err == nil {
-		tx.Rollback()
-		return errors.New("application already exists")
+	now := time.Now()
+	if err := checkApplicationWindow(*scheme, now.Format(utils.DateLayout)); err != nil {
+		return err
 	}
 
 	application := models.Application{
 		ID:          utils.GenerateUUID(),
 		ApplicantID: applicantID,
 		SchemeID:    schemeID,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		// Pinned to the terms the eligibility check below is made against
+		SchemeVersion: scheme.Version,
+		Status:        data.APPLICATION_STATUS_SUBMITTED,
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
 
-	if err := tx.Create(&application).Error; err != nil {
-		tx.Rollback()
-		return err
+	// Age based criteria are evaluated as of the application date
+	if failures := checkCriteria(*applicant, scheme.Criteria, now); len(failures) > 0 {
+		if override == nil {
+			return &EligibilityError{Failures: failures}
+		}
+
+		if override.Actor == "" || override.Reason == "" {
+			return errors.New("actor and reason are required to override eligibility")
+		}
+
+		application.EligibilityOverridden = true
+		application.OverriddenBy = override.Actor
+		application.OverrideReason = override.Reason
+		application.OverriddenAt = &now
 	}
 
-	return tx.Commit().Error
-}
+	return s.Store.Transaction(func(tx repository.Store) error {
+		if _, err := tx.Applications().FindByApplicantAndScheme(applicantID, schemeID); err == nil {
+			return errors.New("application already exists")
+		}
 
-// RETRIEVE Applicantion by Applicant ID or Scheme ID
-func (s *ApplicationService) GetApplications(applicantID, schemeID string) ([]models.Application, error) {
-	var applications []models.Application
-	query := s.DB
+		if err := tx.Applications().Create(&application); err != nil {
+			return err
+		}
 
-	if applicantID != "" {
-		query = query.Where("applicant_id = ?", applicantID)
-	}
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, application.ID, data.AUDIT_ACTION_CREATE, nil, application)
+	})
+}
 
-	if schemeID != "" {
-		query = query.Where("scheme_id = ?", schemeID)
+// RETRIEVE Applications, optionally filtered by Applicant ID, Scheme ID and Status
+func (s *ApplicationService) GetApplications(filter dto.ApplicationFilter, opts dto.ListOptions) ([]models.Application, *dto.CursorPagination, error) {
+	if err := utils.ValidateSortField(opts.Sort, data.APPLICATION_SORT_FIELDS); err != nil {
+		return nil, nil, err
 	}
 
-	if err := query.Find(&applications).Error; err != nil {
-		return nil, err
+	applications, next, err := s.Store.Applications().ListPage(filter, opts)
+	if err != nil {
+		return nil, nil, listError(err, "failed to retrieve applications")
 	}
 
-	return applications, nil
+	return applications, &dto.CursorPagination{Limit: opts.Limit, NextCursor: next}, nil
+}
+
+// UPDATE Application by ID
+func (s *ApplicationService) UpdateApplication(id string, updatedData *models.Application, actor string) error {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		application, err := tx.Applications().FindByID(id)
+		if err != nil {
+			return errors.New("application not found")
+		}
+
+		if application.Status != data.APPLICATION_STATUS_SUBMITTED {
+			return errors.New("only submitted applications can be updated")
+		}
+
+		applicant, err := tx.Applicants().FindByID(updatedData.ApplicantID)
+		if err != nil {
+			return errors.New("applicant not found")
+		}
+
+		scheme, err := tx.Schemes().FindByID(updatedData.SchemeID)
+		if err != nil {
+			return errors.New("scheme not found")
+		}
+
+		// Moving to another scheme is a new application to that scheme
+		if updatedData.SchemeID != application.SchemeID {
+			if err := checkApplicationWindow(*scheme, time.Now().Format(utils.DateLayout)); err != nil {
+				return err
+			}
+		}
+
+		// Overrides only apply to the applicant and scheme they were granted for
+		changed := updatedData.ApplicantID != application.ApplicantID || updatedData.SchemeID != application.SchemeID
+		if changed {
+			if failures := checkCriteria(*applicant, scheme.Criteria, application.CreatedAt); len(failures) > 0 {
+				return &EligibilityError{Failures: failures}
+			}
+		}
+
+		duplicate, err := tx.Applications().FindByApplicantAndScheme(updatedData.ApplicantID, updatedData.SchemeID)
+		if err == nil && duplicate.ID != id {
+			return errors.New("an application with these details already exists")
+		}
+
+		before := *application
+
+		if changed {
+			application.ApplicantID = updatedData.ApplicantID
+			application.SchemeID = updatedData.SchemeID
+			application.SchemeVersion = scheme.Version
+			application.EligibilityOverridden = false
+			application.OverriddenBy = ""
+			application.OverrideReason = ""
+			application.OverriddenAt = nil
+		}
+		application.UpdatedAt = time.Now()
+
+		if err := tx.Applications().Update(application); err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, id, data.AUDIT_ACTION_UPDATE, before, *application)
+	})
+}
+
+// DELETE Application, it can be restored until it is purged
+func (s *ApplicationService) DeleteApplication(applicationID, actor string) error {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		application, err := tx.Applications().FindByID(applicationID)
+		if err != nil {
+			return errors.New("application not found")
+		}
+
+		if err := tx.Applications().Delete(applicationID); err != nil {
+			return err
+		}
+
+		if err := releaseCapacity(tx, *application); err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, applicationID, data.AUDIT_ACTION_DELETE, *application, nil)
+	})
 }
 
-// RETRIEVE All Applications
-func (s *ApplicationService) GetAllApplications() ([]models.Application, error) {
-	var applications []models.Application
-	if err := s.DB.Find(&applications).Error; err != nil {
+// RESTORE Deleted Application by ID
+func (s *ApplicationService) RestoreApplication(id, actor string) (*models.Application, error) {
+	var application *models.Application
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		var err error
+		application, err = tx.Applications().FindDeleted(id)
+		if err != nil {
+			return errors.New("deleted application not found")
+		}
+
+		if _, err := tx.Applicants().FindByID(application.ApplicantID); err != nil {
+			return errors.New("applicant not found, restore the applicant first")
+		}
+
+		if _, err := tx.Schemes().FindByID(application.SchemeID); err != nil {
+			return errors.New("scheme not found, restore the scheme first")
+		}
+
+		if _, err := tx.Applications().FindByApplicantAndScheme(application.ApplicantID, application.SchemeID); err == nil {
+			return errors.New("application already exists")
+		}
+
+		if err := tx.Applications().Restore(id); err != nil {
+			return errors.New("failed to restore application")
+		}
+
+		if err := restoreCapacity(tx, application); err != nil {
+			return err
+		}
+
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, id, data.AUDIT_ACTION_RESTORE, nil, *application)
+	})
+	if err != nil {
 		return nil, err
 	}
-	return applications, nil
+
+	return application, nil
 }
 
-// UPDATE Application by ID
-func (s *ApplicationService) UpdateApplication(id string, updatedData *models.Application) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
+// DELETE Application by Applicant ID
+func (s *ApplicationService) DeleteApplicationByApplicantID(applicantID, actor string) error {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		return deleteApplicantApplications(tx, applicantID, actor)
+	})
+}
+
+// UPDATE Application Status
+func (s *ApplicationService) TransitionApplication(id, toStatus, changedBy, reason string) (*models.Application, error) {
+	if changedBy == "" {
+		return nil, errors.New("actor is required for a status change")
 	}
 
-	var application models.Application
+	if reason == "" && (toStatus == data.APPLICATION_STATUS_REJECTED || toStatus == data.APPLICATION_STATUS_WITHDRAWN) {
+		return nil, errors.New("a reason is required to reject or withdraw an application")
+	}
 
-	if err := tx.First(&application, "id = ?", id).Error; err != nil {
-		tx.Rollback()
-		return errors.New("application not found")
+	var application *models.Application
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		var err error
+		application, err = tx.Applications().FindByID(id)
+		if err != nil {
+			return errors.New("application not found")
+		}
+
+		if err := utils.ValidateStatusTransition(application.Status, toStatus); err != nil {
+			return err
+		}
+
+		before := *application
+		fromStatus := application.Status
+		application.Status = toStatus
+		application.UpdatedAt = time.Now()
+
+		if toStatus == data.APPLICATION_STATUS_APPROVED {
+			application.ApprovedOn = application.UpdatedAt.Format(utils.DateLayout)
+			if err := reserveCapacity(tx, application); err != nil {
+				return err
+			}
+		}
+
+		// Guard on the current status so two concurrent transitions cannot both succeed
+		updated, err := tx.Applications().UpdateStatus(application, fromStatus)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return errors.New("application status was changed by another request")
+		}
+
+		err = tx.Applications().AddStatusChange(&models.ApplicationStatusChange{
+			ID:            utils.GenerateUUID(),
+			ApplicationID: application.ID,
+			FromStatus:    fromStatus,
+			ToStatus:      toStatus,
+			ChangedBy:     changedBy,
+			Reason:        reason,
+			ChangedAt:     application.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(tx, changedBy, data.AUDIT_ENTITY_APPLICATION, application.ID, data.AUDIT_ACTION_STATUS_CHANGE, before, *application)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	var applicantExists bool
-	if err := tx.Model(&models.Applicant{}).
-		Select("count(*) > 0").
-		Where("id = ?", updatedData.ApplicantID).
-		Find(&applicantExists).Error; err != nil || !applicantExists {
-		tx.Rollback()
-		return errors.New("applicant not found")
+	return application, nil
+}
+
+// RETRIEVE Application Status History
+func (s *ApplicationService) GetApplicationHistory(id string) ([]models.ApplicationStatusChange, error) {
+	if _, err := s.Store.Applications().FindByID(id); err != nil {
+		return nil, errors.New("application not found")
 	}
 
-	var schemeExists bool
-	if err := tx.Model(&models.Scheme{}).
-		Select("count(*) > 0").
-		Where("id = ?", updatedData.SchemeID).
-		Find(&schemeExists).Error; err != nil || !schemeExists {
-		tx.Rollback()
-		return errors.New("scheme not found")
+	return s.Store.Applications().ListStatusChanges(id)
+}
+
+/* Helper Functions */
+
+// deleteApplicantApplications deletes every application of an applicant, recording each one in the audit log
+func deleteApplicantApplications(tx repository.Store, applicantID, actor string) error {
+	applications, err := tx.Applications().ListByApplicant(applicantID)
+	if err != nil {
+		return errors.New("failed to retrieve applicant's applications")
 	}
 
-	var duplicateCheck models.Application
-	if err := tx.Where("applicant_id = ? AND scheme_id = ? AND id != ?",
-		updatedData.ApplicantID, updatedData.SchemeID, id).
-		First(&duplicateCheck).Error; err == nil {
-		tx.Rollback()
-		return errors.New("an application with these details already exists")
+	if err := tx.Applications().DeleteByApplicantID(applicantID); err != nil {
+		return errors.New("failed to delete applicant's application")
 	}
 
-	application.ApplicantID = updatedData.ApplicantID
-	application.SchemeID = updatedData.SchemeID
-	application.UpdatedAt = time.Now()
+	for _, application := range applications {
+		if err := releaseCapacity(tx, application); err != nil {
+			return err
+		}
 
-	if err := tx.Save(&application).Error; err != nil {
-		tx.Rollback()
-		return err
+		if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, application.ID, data.AUDIT_ACTION_DELETE, application, nil); err != nil {
+			return err
+		}
 	}
 
-	return tx.Commit().Error
+	return nil
 }
 
-// DELETE Application
-func (s *ApplicationService) DeleteApplication(applicationID string) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
+// reserveCapacity reserves every installment of the benefits of the scheme version the application was
+// submitted against, recording the amount on the application so it can be released exactly
+func reserveCapacity(tx repository.Store, application *models.Application) error {
+	version, err := tx.Schemes().FindVersion(application.SchemeID, application.SchemeVersion)
+	if err != nil {
+		return errors.New("scheme version of the application not found")
 	}
 
-	var application models.Application
+	applicant, err := tx.Applicants().FindByID(application.ApplicantID)
+	if err != nil {
+		return errors.New("applicant not found")
+	}
 
-	if err := tx.First(&application, "id = ?", applicationID).Error; err != nil {
-		tx.Rollback()
-		return errors.New("application not found")
+	// Formula amounts are projected from the household on approval
+	var amount models.Money
+	for _, benefit := range version.Benefits {
+		for _, installment := range utils.InstallmentSchedule(benefit.Recurrence, application.ApprovedOn) {
+			amount += installmentAmount(*applicant, benefit, installment)
+		}
 	}
 
-	if err := tx.Delete(&application).Error; err != nil {
-		tx.Rollback()
-		return err
+	reserved, err := tx.Schemes().ReserveCapacity(application.SchemeID, amount)
+	if err != nil {
+		return errors.New("failed to reserve scheme capacity")
+	}
+
+	if !reserved {
+		scheme, err := tx.Schemes().FindByID(application.SchemeID)
+		if err != nil {
+			return errors.New("scheme not found")
+		}
+
+		if scheme.MaxRecipients != nil && scheme.ReservedRecipients >= *scheme.MaxRecipients {
+			return &CapacityError{Reason: fmt.Sprintf("all %d recipient slots are taken", *scheme.MaxRecipients)}
+		}
+		if scheme.Budget != nil {
+			return &CapacityError{Reason: fmt.Sprintf("%s %s of the budget remains and %s is required", scheme.Currency, *scheme.Budget-scheme.ReservedBudget, amount)}
+		}
+		// The caps changed since the reservation was attempted
+		return &CapacityError{Reason: "scheme capacity exhausted"}
 	}
 
-	return tx.Commit().Error
+	application.ReservedAmount = amount
+	return nil
 }
 
-// DELETE Application by Applicant ID
-func (s *ApplicationService) DeleteApplicationByApplicantID(applicantID string) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
+// releaseCapacity gives back the capacity held by an approved application
+func releaseCapacity(tx repository.Store, application models.Application) error {
+	if application.Status != data.APPLICATION_STATUS_APPROVED {
+		return nil
+	}
+
+	if err := tx.Schemes().ReleaseCapacity(application.SchemeID, application.ReservedAmount); err != nil {
+		return errors.New("failed to release scheme capacity")
 	}
+	return nil
+}
 
-	if err := tx.Where("applicant_id = ?", applicantID).Delete(&models.Application{}).Error; err != nil {
-		tx.Rollback()
+// restoreCapacity reserves capacity again for a restored approved application
+func restoreCapacity(tx repository.Store, application *models.Application) error {
+	if application.Status != data.APPLICATION_STATUS_APPROVED {
+		return nil
+	}
+
+	if err := reserveCapacity(tx, application); err != nil {
 		return err
 	}
 
-	return tx.Commit().Error
+	if _, err := tx.Applications().UpdateStatus(application, application.Status); err != nil {
+		return errors.New("failed to restore application")
+	}
+	return nil
 }
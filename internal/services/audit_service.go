@@ -0,0 +1,91 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+)
+
+type AuditService struct {
+	Store repository.Store
+}
+
+func NewAuditService(store repository.Store) *AuditService {
+	return &AuditService{Store: store}
+}
+
+/* Service Functions */
+
+// RETRIEVE Audit Entries, optionally filtered by Actor, Entity and Action
+func (s *AuditService) GetAuditEntries(filter dto.AuditFilter, opts dto.ListOptions) ([]models.AuditEntry, *dto.CursorPagination, error) {
+	if err := utils.ValidateSortField(opts.Sort, data.AUDIT_SORT_FIELDS); err != nil {
+		return nil, nil, err
+	}
+
+	if filter.EntityType != "" && !data.AUDIT_ENTITY_TYPES[filter.EntityType] {
+		return nil, nil, fmt.Errorf("invalid entity type: '%s'", filter.EntityType)
+	}
+
+	if filter.Action != "" && !data.AUDIT_ACTIONS[filter.Action] {
+		return nil, nil, fmt.Errorf("invalid audit action: '%s'", filter.Action)
+	}
+
+	entries, next, err := s.Store.Audit().ListPage(filter, opts)
+	if err != nil {
+		return nil, nil, listError(err, "failed to retrieve audit entries")
+	}
+
+	return entries, &dto.CursorPagination{Limit: opts.Limit, NextCursor: next}, nil
+}
+
+/* Helper Functions */
+
+// recordAudit appends a change to the audit log inside the caller's transaction,
+// together with the domain event that announces it.
+// before and after are nil for the side of a create or delete where the entity does not exist.
+func recordAudit(tx repository.Store, actor, entityType, entityID, action string, before, after interface{}) error {
+	if actor == "" {
+		return errors.New("actor is required to record a change")
+	}
+
+	entry := models.AuditEntry{
+		ID:         utils.GenerateUUID(),
+		Actor:      actor,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		CreatedAt:  time.Now(),
+	}
+
+	var err error
+	if entry.Before, err = snapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = snapshot(after); err != nil {
+		return err
+	}
+
+	if err := tx.Audit().Append(&entry); err != nil {
+		return errors.New("failed to record audit entry")
+	}
+	return recordEvent(tx, entry)
+}
+
+func snapshot(state interface{}) (models.Snapshot, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(state)
+	if err != nil {
+		return nil, errors.New("failed to encode audit snapshot")
+	}
+	return bytes, nil
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+)
+
+/* Helper Functions */
+func isEligible(applicant models.Applicant, criteria models.Criteria, asOf time.Time) bool {
+	return len(checkCriteria(applicant, criteria, asOf)) == 0
+}
+
+// checkCriteria returns every criterion the applicant fails, or nil if eligible.
+// Age based criteria are evaluated on the reference date asOf.
+func checkCriteria(applicant models.Applicant, criteria models.Criteria, asOf time.Time) []dto.CriterionFailure {
+	result := evaluateCriteria(applicant, criteria, asOf)
+	if result == nil || result.Passed {
+		return nil
+	}
+
+	return collectFailures(*result)
+}
+
+// evaluateCriteria returns the full evaluation trace, or nil when the scheme has no criteria
+func evaluateCriteria(applicant models.Applicant, criteria models.Criteria, asOf time.Time) *dto.CriterionResult {
+	if criteria.Rule == nil {
+		return nil
+	}
+
+	result := evaluateCriteriaNode(applicant, *criteria.Rule, asOf)
+	return &result
+}
+
+// evaluateCriteriaNode evaluates every node of the tree without short-circuiting,
+// so the trace shows the outcome of each criterion
+func evaluateCriteriaNode(applicant models.Applicant, node models.CriteriaNode, asOf time.Time) dto.CriterionResult {
+	result := dto.CriterionResult{
+		Type:        node.Type,
+		Description: dto.DescribeCriteriaNode(node),
+	}
+
+	switch node.Type {
+	case data.CRITERIA_NODE_AND:
+		result.Passed = true
+		for _, child := range node.Nodes {
+			childResult := evaluateCriteriaNode(applicant, child, asOf)
+			result.Passed = result.Passed && childResult.Passed
+			result.Nodes = append(result.Nodes, childResult)
+		}
+
+	case data.CRITERIA_NODE_OR:
+		for _, child := range node.Nodes {
+			childResult := evaluateCriteriaNode(applicant, child, asOf)
+			result.Passed = result.Passed || childResult.Passed
+			result.Nodes = append(result.Nodes, childResult)
+		}
+
+	case data.CRITERIA_NODE_NOT:
+		result.Passed = true
+		if len(node.Nodes) > 0 {
+			childResult := evaluateCriteriaNode(applicant, node.Nodes[0], asOf)
+			result.Passed = !childResult.Passed
+			result.Nodes = append(result.Nodes, childResult)
+		}
+		if !result.Passed {
+			result.Actual = "condition was met"
+		}
+
+	default:
+		evaluateCriteriaPredicate(applicant, node, asOf, &result)
+	}
+
+	return result
+}
+
+// evaluateCriteriaPredicate checks a single predicate, recording the applicant's actual
+// value and, for household predicates, which members matched or missed it
+func evaluateCriteriaPredicate(applicant models.Applicant, node models.CriteriaNode, asOf time.Time, result *dto.CriterionResult) {
+	referenceDate := asOf.Format(utils.DateLayout)
+
+	switch node.Type {
+	case data.CRITERIA_NODE_EMPLOYMENT_STATUS:
+		result.Passed = applicant.EmploymentStatus == node.Value
+		result.Actual = applicant.EmploymentStatus
+
+	case data.CRITERIA_NODE_SEX:
+		result.Passed = applicant.Sex == node.Value
+		result.Actual = applicant.Sex
+
+	case data.CRITERIA_NODE_HAS_RELATION:
+		for _, householdMember := range applicant.Household {
+			addMemberResult(result, householdMember, householdMember.Relation == node.Value, householdMember.Relation)
+		}
+		result.Actual = fmt.Sprintf("%d matching household member(s)", len(result.MatchedMembers))
+
+	case data.CRITERIA_NODE_HAS_CHILDREN:
+		for _, householdMember := range applicant.Household {
+			if householdMember.Relation != data.RELATION_SON && householdMember.Relation != data.RELATION_DAUGHTER {
+				continue
+			}
+
+			matched := compareCondition(node.Condition, householdMember.SchoolLevel, node.SchoolLevel)
+			detail := "school level " + data.SCHOOL_LEVEL_TYPE_ID_MAP[householdMember.SchoolLevel]
+			addMemberResult(result, householdMember, matched, detail)
+		}
+		result.Actual = fmt.Sprintf("%d matching child(ren)", len(result.MatchedMembers))
+
+	case data.CRITERIA_NODE_HOUSEHOLD_SIZE:
+		// Household size counts the applicant together with their household members
+		size := len(applicant.Household) + 1
+		result.Passed = compareCondition(node.Condition, size, node.Count)
+		result.Actual = strconv.Itoa(size)
+
+	case data.CRITERIA_NODE_APPLICANT_AGE:
+		age, err := utils.AgeOn(applicant.DateOfBirth, asOf)
+		if err != nil {
+			result.Actual = err.Error()
+			return
+		}
+		result.Passed = compareCondition(node.Condition, age, node.Age)
+		result.Actual = fmt.Sprintf("%d as of %s", age, referenceDate)
+
+	case data.CRITERIA_NODE_MEMBER_AGE:
+		for _, householdMember := range applicant.Household {
+			if node.Value != "" && householdMember.Relation != node.Value {
+				continue
+			}
+
+			age, err := utils.AgeOn(householdMember.DateOfBirth, asOf)
+			if err != nil {
+				addMemberResult(result, householdMember, false, err.Error())
+				continue
+			}
+
+			addMemberResult(result, householdMember, compareCondition(node.Condition, age, node.Age), fmt.Sprintf("age %d", age))
+		}
+		result.Actual = fmt.Sprintf("%d matching household member(s) as of %s", len(result.MatchedMembers), referenceDate)
+
+	default:
+		log.Printf("[ERROR] Unknown criteria type: %v", node.Type)
+		result.Actual = "unknown criteria type"
+	}
+}
+
+// addMemberResult records whether a household member satisfied a predicate.
+// The predicate passes as soon as one member matches.
+func addMemberResult(result *dto.CriterionResult, member models.HouseholdMember, matched bool, detail string) {
+	memberResult := dto.MemberResult{
+		ID:     member.ID,
+		Name:   member.Name,
+		Detail: detail,
+	}
+
+	if matched {
+		result.Passed = true
+		result.MatchedMembers = append(result.MatchedMembers, memberResult)
+	} else {
+		result.MissedMembers = append(result.MissedMembers, memberResult)
+	}
+}
+
+// collectFailures flattens a failed trace into the predicates responsible for the failure
+func collectFailures(result dto.CriterionResult) []dto.CriterionFailure {
+	if result.Passed {
+		return nil
+	}
+
+	switch result.Type {
+	case data.CRITERIA_NODE_AND, data.CRITERIA_NODE_OR:
+		var failures []dto.CriterionFailure
+		for _, child := range result.Nodes {
+			failures = append(failures, collectFailures(child)...)
+		}
+		return failures
+
+	default:
+		return []dto.CriterionFailure{{
+			Criterion: result.Type,
+			Expected:  result.Description,
+			Actual:    result.Actual,
+		}}
+	}
+}
+
+func compareCondition(condition, actual, expected int) bool {
+	switch condition {
+	case data.CRITERIA_EQUAL:
+		return actual == expected
+	case data.CRITERIA_EQUAL_OR_ABOVE:
+		return actual >= expected
+	case data.CRITERIA_EQUAL_OR_BELOW:
+		return actual <= expected
+	case data.CRITERIA_ABOVE:
+		return actual > expected
+	case data.CRITERIA_BELOW:
+		return actual < expected
+	default:
+		log.Printf("[ERROR] Unknown condition: %v", condition)
+		return false
+	}
+}
+
+/* Eligibility Matrix */
+
+const eligibilityBatchSize = 500
+
+// criteriaDependsOnDate reports whether the outcome can change with the reference date alone
+func criteriaDependsOnDate(node *models.CriteriaNode) bool {
+	if node == nil {
+		return false
+	}
+
+	if node.Type == data.CRITERIA_NODE_APPLICANT_AGE || node.Type == data.CRITERIA_NODE_MEMBER_AGE {
+		return true
+	}
+
+	for i := range node.Nodes {
+		if criteriaDependsOnDate(&node.Nodes[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storeEligibility evaluates every applicant against every scheme and upserts the results.
+// Applicants must have their household loaded.
+func storeEligibility(store repository.Store, applicants []models.Applicant, schemes []models.Scheme, asOf time.Time) error {
+	evaluatedOn := asOf.Format(utils.DateLayout)
+	now := time.Now()
+
+	rows := make([]models.ApplicantSchemeEligibility, 0, len(applicants)*len(schemes))
+	for _, applicant := range applicants {
+		for _, scheme := range schemes {
+			rows = append(rows, models.ApplicantSchemeEligibility{
+				ApplicantID: applicant.ID,
+				SchemeID:    scheme.ID,
+				Eligible:    isEligible(applicant, scheme.Criteria, asOf),
+				EvaluatedOn: evaluatedOn,
+				UpdatedAt:   now,
+			})
+		}
+	}
+
+	return store.Eligibility().Upsert(rows)
+}
+
+// refreshApplicantEligibility recomputes the applicant's row for every scheme
+func refreshApplicantEligibility(store repository.Store, applicant models.Applicant, asOf time.Time) error {
+	schemes, err := store.Schemes().List()
+	if err != nil {
+		return err
+	}
+
+	return storeEligibility(store, []models.Applicant{applicant}, schemes, asOf)
+}
+
+// refreshSchemeEligibility recomputes the scheme's row for every applicant
+func refreshSchemeEligibility(store repository.Store, scheme models.Scheme, asOf time.Time) error {
+	return store.Applicants().ForEachBatch(eligibilityBatchSize, func(applicants []models.Applicant) error {
+		return storeEligibility(store, applicants, []models.Scheme{scheme}, asOf)
+	})
+}
+
+// ensureApplicantEligibility fills in the applicant's missing rows and those
+// evaluated on an earlier date for schemes with age criteria
+func ensureApplicantEligibility(store repository.Store, applicant models.Applicant, schemes []models.Scheme, asOf time.Time) error {
+	rows, err := store.Eligibility().ListByApplicant(applicant.ID)
+	if err != nil {
+		return err
+	}
+
+	evaluatedOn := make(map[string]string, len(rows))
+	for _, row := range rows {
+		evaluatedOn[row.SchemeID] = row.EvaluatedOn
+	}
+
+	referenceDate := asOf.Format(utils.DateLayout)
+
+	var outdated []models.Scheme
+	for _, scheme := range schemes {
+		on, ok := evaluatedOn[scheme.ID]
+		if !ok || (on != referenceDate && criteriaDependsOnDate(scheme.Criteria.Rule)) {
+			outdated = append(outdated, scheme)
+		}
+	}
+
+	return storeEligibility(store, []models.Applicant{applicant}, outdated, asOf)
+}
+
+// ensureSchemeEligibility fills in the scheme's missing rows, and rows evaluated
+// on an earlier date when the scheme has age criteria
+func ensureSchemeEligibility(store repository.Store, scheme models.Scheme, asOf time.Time) error {
+	referenceDate := asOf.Format(utils.DateLayout)
+	outdated := criteriaDependsOnDate(scheme.Criteria.Rule)
+
+	return store.Eligibility().ForEachApplicantToEvaluate(scheme.ID, referenceDate, outdated, eligibilityBatchSize, func(applicants []models.Applicant) error {
+		return storeEligibility(store, applicants, []models.Scheme{scheme}, asOf)
+	})
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+)
+
+/* Helper Functions */
+
+// computeEntitlements returns the amount of every benefit the applicant is entitled to per installment,
+// with ages evaluated on asOf, and their total
+func computeEntitlements(applicant models.Applicant, benefits []models.Benefit, asOf time.Time) ([]dto.Entitlement, models.Money) {
+	entitlements := make([]dto.Entitlement, len(benefits))
+	var total models.Money
+	for i, benefit := range benefits {
+		entitlements[i] = computeEntitlement(applicant, benefit, asOf)
+		total += entitlements[i].Amount
+	}
+	return entitlements, total
+}
+
+// computeEntitlement adds what each household member contributes through the benefit's formula to its base amount.
+// The result is capped at the largest amount a benefit may pay.
+func computeEntitlement(applicant models.Applicant, benefit models.Benefit, asOf time.Time) dto.Entitlement {
+	entitlement := dto.Entitlement{
+		BenefitID:   benefit.ID,
+		BenefitName: benefit.Name,
+		BaseAmount:  benefit.Amount,
+		Amount:      benefit.Amount,
+	}
+
+	if benefit.Formula == nil {
+		return entitlement
+	}
+
+	for i, rule := range benefit.Formula.Rules {
+		var members []dto.EntitlementMember
+		for _, householdMember := range applicant.Household {
+			amount, ok := ruleAmount(rule, householdMember, asOf)
+			if !ok {
+				continue
+			}
+
+			members = append(members, dto.EntitlementMember{
+				Rule:   i + 1,
+				ID:     householdMember.ID,
+				Name:   householdMember.Name,
+				Amount: amount,
+			})
+		}
+
+		// Members that add the most are counted first, ties keep household order
+		if rule.MaxMembers > 0 && len(members) > rule.MaxMembers {
+			sort.SliceStable(members, func(a, b int) bool {
+				return members[a].Amount > members[b].Amount
+			})
+			members = members[:rule.MaxMembers]
+		}
+
+		for _, member := range members {
+			entitlement.Amount += member.Amount
+		}
+		entitlement.Members = append(entitlement.Members, members...)
+	}
+
+	if entitlement.Amount > data.MAX_BENEFIT_AMOUNT {
+		entitlement.Amount = data.MAX_BENEFIT_AMOUNT
+	}
+
+	return entitlement
+}
+
+// ruleAmount returns what a household member adds through a formula rule, and false when the rule does not count them
+func ruleAmount(rule models.AmountRule, member models.HouseholdMember, asOf time.Time) (models.Money, bool) {
+	if !memberMatches(member, rule.Member, asOf) {
+		return 0, false
+	}
+
+	if len(rule.Tiers) == 0 {
+		return rule.Amount, true
+	}
+
+	for _, tier := range rule.Tiers {
+		if memberMatches(member, tier.Member, asOf) {
+			return tier.Amount, true
+		}
+	}
+	return 0, false
+}
+
+// memberMatches evaluates a household member criteria node against one member, as if they were the whole household
+func memberMatches(member models.HouseholdMember, node models.CriteriaNode, asOf time.Time) bool {
+	return evaluateCriteriaNode(models.Applicant{Household: []models.HouseholdMember{member}}, node, asOf).Passed
+}
+
+// installmentAmount is the amount of a benefit an applicant receives for an installment,
+// with ages evaluated on the installment's due date
+func installmentAmount(applicant models.Applicant, benefit models.Benefit, installment utils.Installment) models.Money {
+	if benefit.Formula == nil {
+		return benefit.Amount
+	}
+
+	dueDate, err := time.Parse(utils.DateLayout, installment.DueDate)
+	if err != nil {
+		dueDate = time.Now()
+	}
+	return computeEntitlement(applicant, benefit, dueDate).Amount
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+)
+
+// EventSink receives dispatched domain events. Delivery is at least once: an event is published
+// again when any sink failed it, so sinks should ignore event IDs they have already handled.
+type EventSink interface {
+	Name() string
+	Publish(ctx context.Context, event dto.Event) error
+}
+
+// EventDispatcher delivers the events of the outbox to every sink
+type EventDispatcher struct {
+	Store repository.Store
+	Sinks []EventSink
+}
+
+func NewEventDispatcher(store repository.Store, sinks []EventSink) *EventDispatcher {
+	return &EventDispatcher{Store: store, Sinks: sinks}
+}
+
+/* Service Functions */
+
+// GENERATE Deliveries of the pending events, oldest first. An event that a sink failed is retried
+// after a delay that doubles with every attempt.
+func (d *EventDispatcher) DispatchPending(ctx context.Context) (*dto.DispatchRun, error) {
+	run := &dto.DispatchRun{}
+	for ctx.Err() == nil {
+		now := time.Now()
+		events, err := d.Store.Outbox().ListPending(now, data.OUTBOX_BATCH_SIZE)
+		if err != nil {
+			return run, errors.New("failed to retrieve pending events")
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			claimed, err := d.Store.Outbox().Claim(event.ID, now, now.Add(data.OUTBOX_LEASE))
+			if err != nil {
+				return run, errors.New("failed to claim event")
+			}
+			if !claimed {
+				continue
+			}
+
+			if err := d.dispatch(ctx, event); err != nil {
+				run.Failed++
+				log.Printf("[ERROR] Event %s (%s) attempt %d: %v", event.ID, event.Type, event.Attempts+1, err)
+				if err := d.Store.Outbox().MarkFailed(event.ID, err.Error(), time.Now().Add(retryDelay(event.Attempts, data.OUTBOX_RETRY_BASE, data.OUTBOX_RETRY_MAX))); err != nil {
+					return run, errors.New("failed to record event failure")
+				}
+				continue
+			}
+
+			run.Dispatched++
+			if err := d.Store.Outbox().MarkDispatched(event.ID, time.Now()); err != nil {
+				return run, errors.New("failed to record dispatched event")
+			}
+		}
+
+		if len(events) < data.OUTBOX_BATCH_SIZE {
+			break
+		}
+	}
+
+	return run, nil
+}
+
+// RunDispatcher dispatches pending events now and then every interval until ctx is cancelled.
+// Dispatched events are deleted once they are older than data.OUTBOX_DISPATCHED_TTL.
+func (d *EventDispatcher) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := d.DispatchPending(ctx)
+		if err != nil {
+			log.Printf("[ERROR] Event dispatcher: %v", err)
+		} else if run.Failed > 0 {
+			log.Printf("Event dispatcher dispatched %d events, %d failed", run.Dispatched, run.Failed)
+		}
+
+		if _, err := d.Store.Outbox().DeleteDispatched(time.Now().Add(-data.OUTBOX_DISPATCHED_TTL)); err != nil {
+			log.Printf("[ERROR] Event dispatcher: failed to delete dispatched events: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/* Helper Functions */
+
+// dispatch publishes an event to every sink, and fails when any of them failed
+func (d *EventDispatcher) dispatch(ctx context.Context, event models.OutboxEvent) error {
+	var failures []string
+	for _, sink := range d.Sinks {
+		if err := sink.Publish(ctx, dto.EventFromModel(event)); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", sink.Name(), err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// retryDelay is the delay before retrying something that failed attempts times before the latest failure.
+// It starts at base and doubles with every attempt up to max.
+func retryDelay(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 0; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// recordEvent writes the domain event of an audited change to the outbox in the same transaction
+func recordEvent(tx repository.Store, entry models.AuditEntry) error {
+	event := models.OutboxEvent{
+		ID:            entry.ID,
+		Type:          entry.EntityType + "." + data.EVENT_ACTION_MAP[entry.Action],
+		EntityType:    entry.EntityType,
+		EntityID:      entry.EntityID,
+		Actor:         entry.Actor,
+		Data:          entry.After,
+		Previous:      entry.Before,
+		CreatedAt:     entry.CreatedAt,
+		NextAttemptAt: entry.CreatedAt,
+	}
+
+	// A deleted or purged entity is described by its last state
+	if event.Data == nil {
+		event.Data, event.Previous = entry.Before, nil
+	}
+
+	if err := tx.Outbox().Append(&event); err != nil {
+		return errors.New("failed to record event")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+)
+
+// LogEventSink writes a line per event to the server log
+type LogEventSink struct{}
+
+func (LogEventSink) Name() string {
+	return data.EVENT_SINK_LOG
+}
+
+func (LogEventSink) Publish(ctx context.Context, event dto.Event) error {
+	log.Printf("Event %s %s %s/%s by %s", event.ID, event.Type, event.EntityType, event.EntityID, event.Actor)
+	return nil
+}
+
+// FileEventSink appends every event as a line of JSON to a file
+type FileEventSink struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewFileEventSink(path string) *FileEventSink {
+	return &FileEventSink{Path: path}
+}
+
+func (s *FileEventSink) Name() string {
+	return data.EVENT_SINK_FILE
+}
+
+func (s *FileEventSink) Publish(ctx context.Context, event dto.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WebhookEventSink queues a delivery of every event for each webhook subscribed to it.
+// The deliveries are sent by the webhook delivery worker.
+type WebhookEventSink struct {
+	Store repository.Store
+}
+
+func (WebhookEventSink) Name() string {
+	return data.EVENT_SINK_WEBHOOK
+}
+
+func (s WebhookEventSink) Publish(ctx context.Context, event dto.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return queueWebhookDeliveries(s.Store, event, payload)
+}
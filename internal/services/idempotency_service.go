@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+type IdempotencyService struct {
+	Store repository.Store
+}
+
+func NewIdempotencyService(store repository.Store) *IdempotencyService {
+	return &IdempotencyService{Store: store}
+}
+
+/* Service Functions */
+
+// CREATE Idempotency Key for a request. Returns the key with its stored response when the request
+// already completed, or nil when the caller holds the key and should run the request.
+func (s *IdempotencyService) Begin(actor, key, requestHash string) (*models.IdempotencyRecord, error) {
+	now := time.Now()
+	lockedUntil := now.Add(data.IDEMPOTENCY_LEASE)
+	record := &models.IdempotencyRecord{
+		Actor:       actor,
+		Key:         key,
+		RequestHash: requestHash,
+		LockedUntil: &lockedUntil,
+		CreatedAt:   now,
+	}
+
+	created, err := s.Store.Idempotency().Create(record)
+	if err != nil || created {
+		return nil, err
+	}
+
+	// An expired key is reused, and a request whose lease expired is run again
+	claimed, err := s.Store.Idempotency().Claim(record, now, now.Add(-data.IDEMPOTENCY_KEY_TTL))
+	if err != nil || claimed {
+		return nil, err
+	}
+
+	existing, err := s.Store.Idempotency().Find(actor, key)
+	if errors.Is(err, repository.ErrNotFound) {
+		// Released by a request that failed since, the client can retry
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if existing.CompletedAt == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	return existing, nil
+}
+
+// UDPATE Idempotency Key with the response of its request
+func (s *IdempotencyService) Complete(actor, key string, statusCode int, contentType, body string) error {
+	now := time.Now()
+	return s.Store.Idempotency().Complete(&models.IdempotencyRecord{
+		Actor:       actor,
+		Key:         key,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Body:        body,
+		CompletedAt: &now,
+	})
+}
+
+// DELETE Idempotency Key, so a request that failed can be retried with it
+func (s *IdempotencyService) Release(actor, key string) error {
+	return s.Store.Idempotency().Delete(actor, key)
+}
+
+// RunCleanup deletes the expired idempotency keys every interval until ctx is cancelled
+func (s *IdempotencyService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.Store.Idempotency().DeleteExpired(time.Now().Add(-data.IDEMPOTENCY_KEY_TTL))
+		if err != nil {
+			log.Printf("[ERROR] Idempotency key cleanup: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Idempotency key cleanup deleted %d expired keys", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+)
+
+type LedgerService struct {
+	Store repository.Store
+}
+
+func NewLedgerService(store repository.Store) *LedgerService {
+	return &LedgerService{Store: store}
+}
+
+/* Service Functions */
+
+// CREATE Disbursement of an installment of a benefit of an approved application.
+// Without an installment, the earliest one without a scheduled or paid disbursement is used.
+func (s *LedgerService) ScheduleDisbursement(applicationID, benefitID string, installment int, actor, reference string) (*dto.Disbursement, error) {
+	var output dto.Disbursement
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		application, err := tx.Applications().FindByID(applicationID)
+		if err != nil {
+			return errors.New("application not found")
+		}
+
+		if application.Status != data.APPLICATION_STATUS_APPROVED {
+			return errors.New("only approved applications can be paid out")
+		}
+
+		// Benefits are paid on the terms the application was submitted against
+		version, err := tx.Schemes().FindVersion(application.SchemeID, application.SchemeVersion)
+		if err != nil {
+			return errors.New("scheme version of the application not found")
+		}
+
+		var benefit *models.Benefit
+		for i := range version.Benefits {
+			if version.Benefits[i].ID == benefitID {
+				benefit = &version.Benefits[i]
+			}
+		}
+		if benefit == nil {
+			return errors.New("benefit not found in the application's scheme version")
+		}
+
+		applicant, err := tx.Applicants().FindByID(application.ApplicantID)
+		if err != nil {
+			return errors.New("applicant not found")
+		}
+
+		latest, err := latestDisbursements(tx, applicationID)
+		if err != nil {
+			return err
+		}
+
+		var due *utils.Installment
+		for _, scheduled := range utils.InstallmentSchedule(benefit.Recurrence, application.ApprovedOn) {
+			active := disbursementActive(latest, benefitID, scheduled.Number)
+			if scheduled.Number == installment || (installment == 0 && !active) {
+				if active {
+					return errors.New("installment already has a scheduled or paid disbursement")
+				}
+				due = &scheduled
+				break
+			}
+		}
+		if due == nil && installment == 0 {
+			return errors.New("every installment of the benefit already has a scheduled or paid disbursement")
+		}
+		if due == nil {
+			return fmt.Errorf("benefit has no installment %d", installment)
+		}
+
+		entry := newDisbursementEntry(*application, *version, *benefit, *due, installmentAmount(*applicant, *benefit, *due), utils.GenerateUUID(), actor, reference)
+		if err := appendLedgerEntry(tx, entry, data.AUDIT_ACTION_CREATE); err != nil {
+			return err
+		}
+
+		output = dto.DisbursementsFromEntries([]models.LedgerEntry{entry})[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// GENERATE Disbursements of the installments due on or before asOf that were never disbursed.
+// Each application is scheduled in its own transaction, so one failing application does not hold back the rest.
+func (s *LedgerService) GenerateDuePayouts(asOf time.Time, actor string) (*dto.PayoutRun, error) {
+	run := dto.PayoutRun{
+		AsOf:               asOf.Format(utils.DateLayout),
+		Disbursements:      []dto.Disbursement{},
+		FailedApplications: []string{},
+	}
+
+	filter := dto.ApplicationFilter{Status: data.APPLICATION_STATUS_APPROVED}
+	opts := dto.ListOptions{Sort: data.SORT_CREATED_AT, Limit: payoutBatchSize}
+	for {
+		applications, next, err := s.Store.Applications().ListPage(filter, opts)
+		if err != nil {
+			return nil, errors.New("failed to retrieve approved applications")
+		}
+
+		for _, application := range applications {
+			disbursements, err := s.scheduleDueInstallments(application.ID, run.AsOf, actor)
+			if err != nil {
+				run.FailedApplications = append(run.FailedApplications, application.ID)
+				continue
+			}
+			run.Disbursements = append(run.Disbursements, disbursements...)
+		}
+
+		if next == "" {
+			return &run, nil
+		}
+		opts.Cursor = next
+	}
+}
+
+// UPDATE Disbursement by appending a paid, failed or reversed entry
+func (s *LedgerService) RecordDisbursement(id, entryType, actor, reference, reason string) (*dto.Disbursement, error) {
+	if entryType == data.LEDGER_ENTRY_PAID && reference == "" {
+		return nil, errors.New("a payment reference is required to mark a disbursement paid")
+	}
+
+	if reason == "" && (entryType == data.LEDGER_ENTRY_FAILED || entryType == data.LEDGER_ENTRY_REVERSED) {
+		return nil, errors.New("a reason is required to mark a disbursement failed or reversed")
+	}
+
+	var output dto.Disbursement
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		entries, err := tx.Ledger().ListByDisbursement(id)
+		if err != nil {
+			return errors.New("failed to retrieve disbursement")
+		}
+		if len(entries) == 0 {
+			return errors.New("disbursement not found")
+		}
+
+		latest := entries[len(entries)-1]
+		if err := utils.ValidateLedgerTransition(latest.Type, entryType); err != nil {
+			return err
+		}
+
+		entry := latest
+		entry.ID = utils.GenerateUUID()
+		entry.Sequence = latest.Sequence + 1
+		entry.Type = entryType
+		entry.Reference = reference
+		entry.Reason = reason
+		entry.CreatedBy = actor
+		entry.CreatedAt = time.Now()
+
+		if err := appendLedgerEntry(tx, entry, data.AUDIT_ACTION_STATUS_CHANGE); err != nil {
+			return err
+		}
+
+		output = dto.DisbursementsFromEntries(append(entries, entry))[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// RETRIEVE Disbursement by ID
+func (s *LedgerService) GetDisbursement(id string) (*dto.Disbursement, error) {
+	entries, err := s.Store.Ledger().ListByDisbursement(id)
+	if err != nil {
+		return nil, errors.New("failed to retrieve disbursement")
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("disbursement not found")
+	}
+
+	return &dto.DisbursementsFromEntries(entries)[0], nil
+}
+
+// RETRIEVE Disbursements of an Application
+func (s *LedgerService) GetApplicationDisbursements(applicationID string) ([]dto.Disbursement, error) {
+	entries, err := s.Store.Ledger().List(dto.LedgerFilter{ApplicationID: applicationID})
+	if err != nil {
+		return nil, errors.New("failed to retrieve disbursements")
+	}
+
+	return dto.DisbursementsFromEntries(entries), nil
+}
+
+// RETRIEVE Balances of an Applicant, one per currency
+func (s *LedgerService) GetApplicantBalance(applicantID string) ([]dto.LedgerBalance, error) {
+	if _, err := s.Store.Applicants().FindByID(applicantID); err != nil {
+		return nil, errors.New("applicant not found")
+	}
+
+	return s.balance(dto.LedgerFilter{ApplicantID: applicantID})
+}
+
+// RETRIEVE Balances of a Scheme, one per currency
+func (s *LedgerService) GetSchemeBalance(schemeID string) ([]dto.LedgerBalance, error) {
+	if _, err := s.Store.Schemes().FindByID(schemeID); err != nil {
+		return nil, errors.New("scheme not found")
+	}
+
+	return s.balance(dto.LedgerFilter{SchemeID: schemeID})
+}
+
+// RETRIEVE Installments of an Applicant's approved applications due up to until that are not paid
+func (s *LedgerService) GetApplicantInstallments(applicantID string, until time.Time) ([]dto.Installment, error) {
+	applicant, err := s.Store.Applicants().FindByID(applicantID)
+	if err != nil {
+		return nil, errors.New("applicant not found")
+	}
+
+	applications, err := s.Store.Applications().ListByApplicant(applicantID)
+	if err != nil {
+		return nil, errors.New("failed to retrieve applications")
+	}
+
+	today := time.Now().Format(utils.DateLayout)
+	untilDate := until.Format(utils.DateLayout)
+
+	installments := []dto.Installment{}
+	for _, application := range applications {
+		if application.Status != data.APPLICATION_STATUS_APPROVED {
+			continue
+		}
+
+		version, err := s.Store.Schemes().FindVersion(application.SchemeID, application.SchemeVersion)
+		if err != nil {
+			return nil, errors.New("scheme version of the application not found")
+		}
+
+		latest, err := latestDisbursements(s.Store, application.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, benefit := range version.Benefits {
+			for _, scheduled := range utils.InstallmentSchedule(benefit.Recurrence, application.ApprovedOn) {
+				if scheduled.DueDate > untilDate {
+					break
+				}
+
+				installment := dto.Installment{
+					ApplicationID: application.ID,
+					SchemeID:      application.SchemeID,
+					BenefitID:     benefit.ID,
+					BenefitName:   benefit.Name,
+					Installment:   scheduled.Number,
+					DueDate:       scheduled.DueDate,
+					Amount:        installmentAmount(*applicant, benefit, scheduled),
+					Currency:      version.Currency,
+					Status:        data.INSTALLMENT_STATUS_UPCOMING,
+				}
+
+				disbursement, ok := latest[installmentKey(benefit.ID, scheduled.Number)]
+				switch {
+				case ok && disbursement.Status == data.LEDGER_ENTRY_PAID:
+					continue
+				case ok:
+					installment.Status = disbursement.Status
+					installment.DisbursementID = disbursement.ID
+				case scheduled.DueDate <= today:
+					installment.Status = data.INSTALLMENT_STATUS_DUE
+				}
+
+				installments = append(installments, installment)
+			}
+		}
+	}
+
+	sort.SliceStable(installments, func(i, j int) bool {
+		return installments[i].DueDate < installments[j].DueDate
+	})
+
+	return installments, nil
+}
+
+/* Helper Functions */
+
+const payoutBatchSize = 100
+
+// scheduleDueInstallments schedules the installments of an application due on or before asOf that have
+// no disbursement yet. Their disbursement IDs are derived from the installment, so when two runs
+// overlap the unique ledger sequence makes the later one fail instead of paying twice.
+func (s *LedgerService) scheduleDueInstallments(applicationID, asOf, actor string) ([]dto.Disbursement, error) {
+	disbursements := []dto.Disbursement{}
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		application, err := tx.Applications().FindByID(applicationID)
+		if err != nil || application.Status != data.APPLICATION_STATUS_APPROVED {
+			return nil
+		}
+
+		version, err := tx.Schemes().FindVersion(application.SchemeID, application.SchemeVersion)
+		if err != nil {
+			return errors.New("scheme version of the application not found")
+		}
+
+		applicant, err := tx.Applicants().FindByID(application.ApplicantID)
+		if err != nil {
+			return errors.New("applicant not found")
+		}
+
+		latest, err := latestDisbursements(tx, applicationID)
+		if err != nil {
+			return err
+		}
+
+		for _, benefit := range version.Benefits {
+			for _, scheduled := range utils.InstallmentSchedule(benefit.Recurrence, application.ApprovedOn) {
+				if scheduled.DueDate > asOf {
+					break
+				}
+
+				key := installmentKey(benefit.ID, scheduled.Number)
+				if _, ok := latest[key]; ok {
+					continue
+				}
+
+				disbursementID := utils.GenerateNameUUID(application.ID + "/" + key)
+				entry := newDisbursementEntry(*application, *version, benefit, scheduled, installmentAmount(*applicant, benefit, scheduled), disbursementID, actor, "")
+				if err := appendLedgerEntry(tx, entry, data.AUDIT_ACTION_CREATE); err != nil {
+					return err
+				}
+
+				disbursements = append(disbursements, dto.DisbursementsFromEntries([]models.LedgerEntry{entry})[0])
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return disbursements, nil
+}
+
+// newDisbursementEntry is the scheduled entry that starts the disbursement of an installment of amount
+func newDisbursementEntry(application models.Application, version models.SchemeVersion, benefit models.Benefit, installment utils.Installment, amount models.Money, disbursementID, actor, reference string) models.LedgerEntry {
+	return models.LedgerEntry{
+		ID:             utils.GenerateUUID(),
+		DisbursementID: disbursementID,
+		Sequence:       1,
+		ApplicationID:  application.ID,
+		ApplicantID:    application.ApplicantID,
+		SchemeID:       application.SchemeID,
+		BenefitID:      benefit.ID,
+		BenefitName:    benefit.Name,
+		Installment:    installment.Number,
+		DueDate:        installment.DueDate,
+		Type:           data.LEDGER_ENTRY_SCHEDULED,
+		Amount:         amount,
+		Currency:       version.Currency,
+		Reference:      reference,
+		CreatedBy:      actor,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// latestDisbursements returns the most recently scheduled disbursement of each installment of an application
+func latestDisbursements(store repository.Store, applicationID string) (map[string]dto.Disbursement, error) {
+	entries, err := store.Ledger().List(dto.LedgerFilter{ApplicationID: applicationID})
+	if err != nil {
+		return nil, errors.New("failed to retrieve disbursements")
+	}
+
+	latest := map[string]dto.Disbursement{}
+	for _, disbursement := range dto.DisbursementsFromEntries(entries) {
+		latest[installmentKey(disbursement.BenefitID, disbursement.Installment)] = disbursement
+	}
+	return latest, nil
+}
+
+// disbursementActive reports whether an installment has a scheduled or paid disbursement
+func disbursementActive(latest map[string]dto.Disbursement, benefitID string, installment int) bool {
+	disbursement, ok := latest[installmentKey(benefitID, installment)]
+	return ok && (disbursement.Status == data.LEDGER_ENTRY_SCHEDULED || disbursement.Status == data.LEDGER_ENTRY_PAID)
+}
+
+func installmentKey(benefitID string, installment int) string {
+	return fmt.Sprintf("%s/%d", benefitID, installment)
+}
+
+func (s *LedgerService) balance(filter dto.LedgerFilter) ([]dto.LedgerBalance, error) {
+	entries, err := s.Store.Ledger().List(filter)
+	if err != nil {
+		return nil, errors.New("failed to retrieve disbursements")
+	}
+
+	return dto.LedgerBalancesFromDisbursements(dto.DisbursementsFromEntries(entries)), nil
+}
+
+// appendLedgerEntry appends the entry and records it in the audit log. The entry's sequence
+// is unique within its disbursement, so when two requests change a disbursement at once one fails.
+func appendLedgerEntry(tx repository.Store, entry models.LedgerEntry, action string) error {
+	if err := tx.Ledger().Append(&entry); err != nil {
+		return errors.New("failed to record disbursement, it may have been changed by another request")
+	}
+
+	return recordAudit(tx, entry.CreatedBy, data.AUDIT_ENTITY_DISBURSEMENT, entry.DisbursementID, action, nil, entry)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+)
+
+// listError reports an invalid cursor to the caller and hides other storage errors behind message
+func listError(err error, message string) error {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return errors.New("invalid cursor")
+	}
+	return errors.New(message)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+)
+
+// RunPayoutScheduler schedules the disbursements of due installments now and then every interval,
+// until ctx is cancelled
+func (s *LedgerService) RunPayoutScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := s.GenerateDuePayouts(time.Now(), data.PAYOUT_SCHEDULER_ACTOR)
+		if err != nil {
+			log.Printf("[ERROR] Payout scheduler: %v", err)
+		} else if len(run.Disbursements) > 0 || len(run.FailedApplications) > 0 {
+			log.Printf("Payout scheduler scheduled %d disbursements, %d applications failed", len(run.Disbursements), len(run.FailedApplications))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+)
+
+// PurgeService permanently removes records that were soft deleted longer than Retention ago
+type PurgeService struct {
+	Store     repository.Store
+	Retention time.Duration
+}
+
+func NewPurgeService(store repository.Store, retention time.Duration) *PurgeService {
+	return &PurgeService{Store: store, Retention: retention}
+}
+
+/* Service Functions */
+
+// PURGE Applicants, Schemes and Applications deleted before the Retention Period
+func (s *PurgeService) PurgeDeleted(actor string) (*dto.PurgeResult, error) {
+	result := dto.PurgeResult{DeletedBefore: time.Now().Add(-s.Retention)}
+
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		applications, err := tx.Applications().ListDeleted(result.DeletedBefore)
+		if err != nil {
+			return errors.New("failed to retrieve deleted applications")
+		}
+
+		for _, application := range applications {
+			if err := tx.Applications().Purge(application.ID); err != nil {
+				return errors.New("failed to purge application")
+			}
+
+			if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, application.ID, data.AUDIT_ACTION_PURGE, application, nil); err != nil {
+				return err
+			}
+			result.Applications++
+		}
+
+		applicants, err := tx.Applicants().ListDeleted(result.DeletedBefore)
+		if err != nil {
+			return errors.New("failed to retrieve deleted applicants")
+		}
+
+		for _, applicant := range applicants {
+			// Applications deleted with the applicant may be just inside the retention period
+			purged, err := tx.Applications().PurgeByApplicantID(applicant.ID)
+			if err != nil {
+				return errors.New("failed to purge applicant's applications")
+			}
+
+			for _, application := range purged {
+				if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICATION, application.ID, data.AUDIT_ACTION_PURGE, application, nil); err != nil {
+					return err
+				}
+				result.Applications++
+			}
+
+			if err := tx.Applicants().Purge(applicant.ID); err != nil {
+				return errors.New("failed to purge applicant")
+			}
+
+			if err := recordAudit(tx, actor, data.AUDIT_ENTITY_APPLICANT, applicant.ID, data.AUDIT_ACTION_PURGE, dto.ApplicantWithHouseholdFromModel(applicant), nil); err != nil {
+				return err
+			}
+			result.Applicants++
+		}
+
+		schemes, err := tx.Schemes().ListDeleted(result.DeletedBefore)
+		if err != nil {
+			return errors.New("failed to retrieve deleted schemes")
+		}
+
+		for _, scheme := range schemes {
+			referenced, err := tx.Applications().HasScheme(scheme.ID)
+			if err != nil {
+				return errors.New("failed to check scheme applications")
+			}
+
+			if referenced {
+				result.SkippedSchemes++
+				continue
+			}
+
+			if err := tx.Schemes().Purge(scheme.ID); err != nil {
+				return errors.New("failed to purge scheme")
+			}
+
+			if err := recordAudit(tx, actor, data.AUDIT_ENTITY_SCHEME, scheme.ID, data.AUDIT_ACTION_PURGE, dto.SchemeFromModel(scheme), nil); err != nil {
+				return err
+			}
+			result.Schemes++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
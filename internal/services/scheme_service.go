@@ -2,327 +2,475 @@ package services
 
 import (
 	"errors"
-	"log"
+	"fmt"
 	"time"
 
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
-	"gorm.io/gorm"
 )
 
 type SchemeService struct {
-	DB *gorm.DB
+	Store repository.Store
 }
 
-func NewSchemeService(db *gorm.DB) *SchemeService {
-	return &SchemeService{DB: db}
+func NewSchemeService(store repository.Store) *SchemeService {
+	return &SchemeService{Store: store}
 }
 
-/* Helper Functions */
-func isEligible(applicant models.Applicant, criteria models.Criteria) bool {
-	if criteria.EmploymentStatus != "" && applicant.EmploymentStatus != criteria.EmploymentStatus {
-		return false
-	}
-
-	if criteria.HasChildren != nil {
-		hasEligibleChild := false
-		for _, householdMember := range applicant.Household {
-			if householdMember.Relation == data.RELATION_SON || householdMember.Relation == data.RELATION_DAUGHTER {
-
-				childLevel := householdMember.SchoolLevel
-				schemeLevel := criteria.HasChildren.SchoolLevel
-				condition := criteria.HasChildren.SchoolLevelCondition
-
-				switch condition {
-				case data.CRITERIA_EQUAL:
-					if childLevel == schemeLevel {
-						hasEligibleChild = true
-					}
-				case data.CRITERIA_EQUAL_OR_ABOVE:
-					if childLevel >= schemeLevel {
-						hasEligibleChild = true
-					}
-				case data.CRITERIA_EQUAL_OR_BELOW:
-					if childLevel <= schemeLevel {
-						hasEligibleChild = true
-					}
-				case data.CRITERIA_ABOVE:
-					if childLevel > schemeLevel {
-						hasEligibleChild = true
-					}
-				case data.CRITERIA_BELOW:
-					if childLevel < schemeLevel {
-						hasEligibleChild = true
-					}
-				default:
-					log.Printf("[ERROR] Unknown condition: %v", condition)
-				}
-
-				if hasEligibleChild {
-					break
-				}
-			}
-		}
+/* Service Functions */
 
-		if !hasEligibleChild {
-			return false
-		}
+// CREATE Scheme
+func (s *SchemeService) CreateScheme(schemeData *models.Scheme, actor string) error {
+	if err := utils.ValidateScheme(schemeData.Name, schemeData.Criteria); err != nil {
+		return err
 	}
 
-	return true
-}
-
-/* Service Functions */
+	if err := utils.ValidateSchemeWindow(schemeData.EffectiveFrom, schemeData.EffectiveTo, schemeData.ApplicationDeadline); err != nil {
+		return err
+	}
 
-// CREATE Scheme
-func (s *SchemeService) CreateScheme(schemeData *models.Scheme) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
+	if err := utils.ValidateSchemeCaps(schemeData.Budget, schemeData.MaxRecipients); err != nil {
+		return err
 	}
 
-	if err := utils.ValidateScheme(
-		schemeData.Name,
-		schemeData.Criteria.EmploymentStatus,
-		schemeData.Criteria.HasChildren,
-	); err != nil {
+	currency := schemeData.Currency
+	if currency == "" {
+		currency = data.DEFAULT_CURRENCY
+	}
+	if err := utils.ValidateCurrency(currency); err != nil {
 		return err
 	}
 
 	scheme := models.Scheme{
-		ID:   utils.GenerateUUID(),
-		Name: schemeData.Name,
+		ID:      utils.GenerateUUID(),
+		Name:    schemeData.Name,
+		Version: 1,
 		Criteria: models.Criteria{
-			EmploymentStatus: schemeData.Criteria.EmploymentStatus,
-			HasChildren: &models.Children{
-				SchoolLevel:          schemeData.Criteria.HasChildren.SchoolLevel,
-				SchoolLevelCondition: schemeData.Criteria.HasChildren.SchoolLevelCondition,
-			},
+			Version: data.CRITERIA_VERSION,
+			Rule:    schemeData.Criteria.Rule,
 		},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		Currency:            currency,
+		EffectiveFrom:       schemeData.EffectiveFrom,
+		EffectiveTo:         schemeData.EffectiveTo,
+		ApplicationDeadline: schemeData.ApplicationDeadline,
+		Budget:              schemeData.Budget,
+		MaxRecipients:       schemeData.MaxRecipients,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
 	}
 
 	benefits := make([]models.Benefit, len(schemeData.Benefits))
 	for i, benefit := range schemeData.Benefits {
-		if err := utils.ValidateBenefit(benefit.Name, benefit.Amount); err != nil {
-			tx.Rollback()
+		if err := utils.ValidateBenefit(benefit); err != nil {
 			return err
 		}
 
 		benefits[i] = models.Benefit{
-			ID:       utils.GenerateUUID(),
-			Name:     benefit.Name,
-			Amount:   benefit.Amount,
-			SchemeID: scheme.ID,
+			ID:         utils.GenerateUUID(),
+			Name:       benefit.Name,
+			Amount:     benefit.Amount,
+			Recurrence: benefit.Recurrence,
+			Formula:    benefit.Formula,
+			SchemeID:   scheme.ID,
 		}
 	}
 
-	if err := tx.Create(&scheme).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+	scheme.Benefits = benefits
 
-	if len(benefits) > 0 {
-		if err := tx.Create(&benefits).Error; err != nil {
-			tx.Rollback()
+	return s.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Schemes().Create(&scheme); err != nil {
+			return err
+		}
+
+		if err := addSchemeVersion(tx, scheme, actor); err != nil {
+			return err
+		}
+
+		if err := recordAudit(tx, actor, data.AUDIT_ENTITY_SCHEME, scheme.ID, data.AUDIT_ACTION_CREATE, nil, dto.SchemeFromModel(scheme)); err != nil {
 			return err
 		}
-	}
 
-	return tx.Commit().Error
+		if err := refreshSchemeEligibility(tx, scheme, time.Now()); err != nil {
+			return errors.New("failed to compute scheme eligibility")
+		}
+
+		return nil
+	})
 }
 
 // RETRIEVE All Schemes
-func (s *SchemeService) GetAllSchemes() ([]dto.Scheme, error) {
-	var schemes []models.Scheme
-	if err := s.DB.Preload("Benefits").Find(&schemes).Error; err != nil {
-		return nil, err
+func (s *SchemeService) GetAllSchemes(filter dto.SchemeFilter, opts dto.ListOptions) ([]dto.Scheme, *dto.CursorPagination, error) {
+	if err := utils.ValidateSortField(opts.Sort, data.SCHEME_SORT_FIELDS); err != nil {
+		return nil, nil, err
+	}
+
+	schemes, next, err := s.Store.Schemes().ListPage(filter, opts)
+	if err != nil {
+		return nil, nil, listError(err, "failed to retrieve schemes")
 	}
 
 	output := make([]dto.Scheme, len(schemes))
 	for i, scheme := range schemes {
-		benefitDTO := make([]dto.Benefit, len(scheme.Benefits))
-		for j, benefit := range scheme.Benefits {
-			benefitDTO[j] = dto.Benefit{
-				ID:     benefit.ID,
-				Name:   benefit.Name,
-				Amount: benefit.Amount,
-			}
-		}
-
-		output[i] = dto.Scheme{
-			ID:       scheme.ID,
-			Name:     scheme.Name,
-			Criteria: dto.CriteriaFromModel(scheme.Criteria),
-			Benefits: benefitDTO,
-		}
+		output[i] = dto.SchemeFromModel(scheme)
 	}
 
-	return output, nil
+	return output, &dto.CursorPagination{Limit: opts.Limit, NextCursor: next}, nil
 }
 
 // RETRIEVE Scheme by ID
 func (s *SchemeService) GetSchemeByID(id string) (*dto.Scheme, error) {
-	var scheme models.Scheme
-	if err := s.DB.Preload("Benefits").First(&scheme, "id = ?", id).Error; err != nil {
+	scheme, err := s.Store.Schemes().FindByID(id)
+	if err != nil {
 		return nil, errors.New("scheme not found")
 	}
 
-	benefitDTO := make([]dto.Benefit, len(scheme.Benefits))
-	for i, benefit := range scheme.Benefits {
-		benefitDTO[i] = dto.Benefit{
-			ID:     benefit.ID,
-			Name:   benefit.Name,
-			Amount: benefit.Amount,
-		}
+	output := dto.SchemeFromModel(*scheme)
+	return &output, nil
+}
+
+// RETRIEVE Versions of a Scheme, oldest first. Versions of deleted schemes are kept until they are purged.
+func (s *SchemeService) GetSchemeVersions(id string) ([]dto.SchemeVersion, error) {
+	versions, err := s.Store.Schemes().ListVersions(id)
+	if err != nil {
+		return nil, errors.New("failed to retrieve scheme versions")
 	}
 
-	return &dto.Scheme{
-		ID:       scheme.ID,
-		Name:     scheme.Name,
-		Criteria: dto.CriteriaFromModel(scheme.Criteria),
-		Benefits: benefitDTO,
-	}, nil
+	if len(versions) == 0 {
+		return nil, errors.New("scheme not found")
+	}
+
+	output := make([]dto.SchemeVersion, len(versions))
+	for i, version := range versions {
+		output[i] = dto.SchemeVersionFromModel(version)
+	}
+
+	return output, nil
 }
 
-// UDPATE Scheme by ID
-func (s *SchemeService) UpdateScheme(id string, updatedData *models.Scheme) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
+// RETRIEVE Scheme Version
+func (s *SchemeService) GetSchemeVersion(id string, version int) (*dto.SchemeVersion, error) {
+	schemeVersion, err := s.Store.Schemes().FindVersion(id, version)
+	if err != nil {
+		return nil, errors.New("scheme version not found")
 	}
 
-	if err := utils.ValidateScheme(
-		updatedData.Name,
-		updatedData.Criteria.EmploymentStatus,
-		updatedData.Criteria.HasChildren,
-	); err != nil {
+	output := dto.SchemeVersionFromModel(*schemeVersion)
+	return &output, nil
+}
+
+// UDPATE Scheme by ID
+func (s *SchemeService) UpdateScheme(id string, updatedData *models.Scheme, actor string) error {
+	if err := utils.ValidateScheme(updatedData.Name, updatedData.Criteria); err != nil {
 		return err
 	}
 
-	var scheme models.Scheme
+	if err := utils.ValidateSchemeWindow(updatedData.EffectiveFrom, updatedData.EffectiveTo, updatedData.ApplicationDeadline); err != nil {
+		return err
+	}
 
-	if err := tx.First(&scheme, "id = ?", id).Error; err != nil {
-		tx.Rollback()
-		return errors.New("scheme not found")
+	if err := utils.ValidateSchemeCaps(updatedData.Budget, updatedData.MaxRecipients); err != nil {
+		return err
 	}
 
-	scheme.Name = updatedData.Name
-	scheme.Criteria = models.Criteria{
-		EmploymentStatus: updatedData.Criteria.EmploymentStatus,
-		HasChildren: &models.Children{
-			SchoolLevel:          updatedData.Criteria.HasChildren.SchoolLevel,
-			SchoolLevelCondition: updatedData.Criteria.HasChildren.SchoolLevelCondition,
-		},
+	if updatedData.Currency != "" {
+		if err := utils.ValidateCurrency(updatedData.Currency); err != nil {
+			return err
+		}
 	}
-	scheme.UpdatedAt = time.Now()
 
-	var updatedBenefits []models.Benefit
-	for _, benefit := range updatedData.Benefits {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		scheme, err := tx.Schemes().FindByID(id)
+		if err != nil {
+			return errors.New("scheme not found")
+		}
 
-		if err := utils.ValidateBenefit(benefit.Name, benefit.Amount); err != nil {
-			tx.Rollback()
-			return err
+		before := dto.SchemeFromModel(*scheme)
+
+		// Caps can be lowered, but not below what approved applications already hold
+		if updatedData.Budget != nil && *updatedData.Budget < scheme.ReservedBudget {
+			return fmt.Errorf("budget cannot be lower than the %s %s already reserved", scheme.Currency, scheme.ReservedBudget)
 		}
-		if benefit.ID == "" {
-			updatedBenefits = append(updatedBenefits, models.Benefit{
-				ID:       utils.GenerateUUID(),
-				Name:     benefit.Name,
-				Amount:   benefit.Amount,
-				SchemeID: scheme.ID,
-			})
-		} else {
+		if updatedData.MaxRecipients != nil && *updatedData.MaxRecipients < scheme.ReservedRecipients {
+			return fmt.Errorf("max_recipients cannot be lower than the %d approved applications", scheme.ReservedRecipients)
+		}
+
+		// Reserved amounts are in the current currency, so it is fixed while applications hold any
+		if updatedData.Currency != "" && updatedData.Currency != scheme.Currency {
+			if scheme.ReservedRecipients > 0 {
+				return errors.New("currency cannot be changed while approved applications hold reservations")
+			}
+			scheme.Currency = updatedData.Currency
+		}
+
+		scheme.Name = updatedData.Name
+		scheme.Criteria = models.Criteria{
+			Version: data.CRITERIA_VERSION,
+			Rule:    updatedData.Criteria.Rule,
+		}
+		scheme.EffectiveFrom = updatedData.EffectiveFrom
+		scheme.EffectiveTo = updatedData.EffectiveTo
+		scheme.ApplicationDeadline = updatedData.ApplicationDeadline
+		scheme.Budget = updatedData.Budget
+		scheme.MaxRecipients = updatedData.MaxRecipients
+		scheme.Version++
+		scheme.UpdatedAt = time.Now()
+
+		var updatedBenefits []models.Benefit
+		for _, benefit := range updatedData.Benefits {
+
+			if err := utils.ValidateBenefit(benefit); err != nil {
+				return err
+			}
+
+			benefitID := benefit.ID
+			if benefitID == "" {
+				benefitID = utils.GenerateUUID()
+			}
+
 			updatedBenefits = append(updatedBenefits, models.Benefit{
-				ID:       benefit.ID,
-				Name:     benefit.Name,
-				Amount:   benefit.Amount,
-				SchemeID: scheme.ID,
+				ID:         benefitID,
+				Name:       benefit.Name,
+				Amount:     benefit.Amount,
+				Recurrence: benefit.Recurrence,
+				Formula:    benefit.Formula,
+				SchemeID:   scheme.ID,
 			})
 		}
-	}
 
-	if err := tx.Save(&scheme).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
+		scheme.Benefits = updatedBenefits
 
-	if err := tx.Where("scheme_id = ?", scheme.ID).Delete(&models.Benefit{}).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to delete old benefits")
-	}
+		if err := tx.Schemes().Update(scheme); err != nil {
+			return errors.New("failed to update scheme")
+		}
 
-	if len(updatedBenefits) > 0 {
-		if err := tx.Create(&updatedBenefits).Error; err != nil {
-			tx.Rollback()
+		if err := addSchemeVersion(tx, *scheme, actor); err != nil {
+			return err
+		}
+
+		if err := recordAudit(tx, actor, data.AUDIT_ENTITY_SCHEME, scheme.ID, data.AUDIT_ACTION_UPDATE, before, dto.SchemeFromModel(*scheme)); err != nil {
 			return err
 		}
-	}
 
-	return tx.Commit().Error
+		if err := refreshSchemeEligibility(tx, *scheme, time.Now()); err != nil {
+			return errors.New("failed to compute scheme eligibility")
+		}
+
+		return nil
+	})
 }
 
-// DELETE Scheme
-func (s *SchemeService) DeleteScheme(id string) error {
-	tx := s.DB.Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
+// DELETE Scheme, it can be restored until it is purged
+func (s *SchemeService) DeleteScheme(id, actor string) error {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		scheme, err := tx.Schemes().FindByID(id)
+		if err != nil {
+			return errors.New("scheme not found")
+		}
 
-	var scheme models.Scheme
+		if err := tx.Eligibility().DeleteByScheme(id); err != nil {
+			return errors.New("failed to delete scheme eligibility")
+		}
 
-	if err := tx.First(&scheme, "id = ?", id).Error; err != nil {
-		tx.Rollback()
-		return errors.New("scheme not found")
-	}
+		if err := tx.Schemes().Delete(id); err != nil {
+			return errors.New("failed to delete scheme")
+		}
 
-	if err := tx.Where("scheme_id = ?", id).Delete(&models.Benefit{}).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to delete scheme benefits")
-	}
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_SCHEME, id, data.AUDIT_ACTION_DELETE, dto.SchemeFromModel(*scheme), nil)
+	})
+}
 
-	if err := tx.Delete(&scheme).Error; err != nil {
-		tx.Rollback()
-		return errors.New("failed to delete scheme")
+// RESTORE Deleted Scheme by ID
+func (s *SchemeService) RestoreScheme(id, actor string) (*dto.Scheme, error) {
+	var output dto.Scheme
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		scheme, err := tx.Schemes().FindDeleted(id)
+		if err != nil {
+			return errors.New("deleted scheme not found")
+		}
+
+		if err := tx.Schemes().Restore(id); err != nil {
+			return errors.New("failed to restore scheme")
+		}
+
+		if err := refreshSchemeEligibility(tx, *scheme, time.Now()); err != nil {
+			return errors.New("failed to compute scheme eligibility")
+		}
+
+		output = dto.SchemeFromModel(*scheme)
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_SCHEME, id, data.AUDIT_ACTION_RESTORE, nil, output)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return tx.Commit().Error
+	return &output, nil
 }
 
-// RETRIEVE Eligible Schemes
-func (s *SchemeService) GetEligibleSchemes(applicantID string) ([]dto.Scheme, error) {
-	var applicant models.Applicant
-	if err := s.DB.Preload("Household").First(&applicant, "id = ?", applicantID).Error; err != nil {
+// RETRIEVE Eligible Schemes that are open on the reference date asOf
+func (s *SchemeService) GetEligibleSchemes(applicantID string, asOf time.Time) ([]dto.EligibleScheme, error) {
+	applicant, err := s.Store.Applicants().FindByID(applicantID)
+	if err != nil {
 		return nil, errors.New("applicant not found")
 	}
 
-	var schemes []models.Scheme
-	if err := s.DB.Preload("Benefits").Find(&schemes).Error; err != nil {
+	schemes, err := s.Store.Schemes().List()
+	if err != nil {
 		return nil, errors.New("failed to retrieve schemes")
 	}
 
-	eligibleSchemes := []dto.Scheme{}
-	for _, scheme := range schemes {
-		if isEligible(applicant, scheme.Criteria) {
-			benefitDTO := make([]dto.Benefit, len(scheme.Benefits))
-			for i, benefit := range scheme.Benefits {
-				benefitDTO[i] = dto.Benefit{
-					ID:     benefit.ID,
-					Name:   benefit.Name,
-					Amount: benefit.Amount,
-				}
+	referenceDate := asOf.Format(utils.DateLayout)
+
+	// The eligibility table holds today's results, other dates are evaluated live
+	var eligible []models.Scheme
+	if referenceDate == time.Now().Format(utils.DateLayout) {
+		if err := ensureApplicantEligibility(s.Store, *applicant, schemes, asOf); err != nil {
+			return nil, errors.New("failed to compute applicant eligibility")
+		}
+
+		eligible, err = s.Store.Eligibility().ListEligibleSchemes(applicantID)
+		if err != nil {
+			return nil, errors.New("failed to retrieve eligible schemes")
+		}
+	} else {
+		for _, scheme := range schemes {
+			if isEligible(*applicant, scheme.Criteria, asOf) {
+				eligible = append(eligible, scheme)
 			}
+		}
+	}
 
-			eligibleSchemes = append(eligibleSchemes, dto.Scheme{
-				ID:       scheme.ID,
-				Name:     scheme.Name,
-				Criteria: dto.CriteriaFromModel(scheme.Criteria),
-				Benefits: benefitDTO,
+	// Only schemes the applicant could apply for on the reference date are listed
+	eligibleSchemes := []dto.EligibleScheme{}
+	for _, scheme := range eligible {
+		if checkApplicationWindow(scheme, referenceDate) == nil {
+			entitlements, total := computeEntitlements(*applicant, scheme.Benefits, asOf)
+			eligibleSchemes = append(eligibleSchemes, dto.EligibleScheme{
+				Scheme:       dto.SchemeFromModel(scheme),
+				Entitlements: entitlements,
+				TotalAmount:  total,
 			})
 		}
 	}
 
 	return eligibleSchemes, nil
 }
+
+// RETRIEVE Eligibility of an Applicant for every Scheme on the reference date asOf
+func (s *SchemeService) ExplainEligibility(applicantID string, asOf time.Time) ([]dto.SchemeEligibility, error) {
+	applicant, err := s.Store.Applicants().FindByID(applicantID)
+	if err != nil {
+		return nil, errors.New("applicant not found")
+	}
+
+	schemes, err := s.Store.Schemes().List()
+	if err != nil {
+		return nil, errors.New("failed to retrieve schemes")
+	}
+
+	output := make([]dto.SchemeEligibility, len(schemes))
+	for i, scheme := range schemes {
+		trace := evaluateCriteria(*applicant, scheme.Criteria, asOf)
+		output[i] = dto.SchemeEligibility{
+			Scheme:                dto.SchemeFromModel(scheme),
+			Eligible:              trace == nil || trace.Passed,
+			Open:                  schemeOpenOn(scheme, asOf.Format(utils.DateLayout)),
+			AcceptingApplications: checkApplicationWindow(scheme, asOf.Format(utils.DateLayout)) == nil,
+			AsOf:                  asOf.Format(utils.DateLayout),
+			Trace:                 trace,
+		}
+
+		if output[i].Eligible {
+			entitlements, total := computeEntitlements(*applicant, scheme.Benefits, asOf)
+			output[i].Entitlements = entitlements
+			output[i].TotalAmount = &total
+		}
+	}
+
+	return output, nil
+}
+
+// RETRIEVE Applicants Eligible for a Scheme
+func (s *SchemeService) GetEligibleApplicants(schemeID string, page, pageSize int, excludeApplied bool) ([]dto.EligibleApplicant, *dto.Pagination, error) {
+	scheme, err := s.Store.Schemes().FindByID(schemeID)
+	if err != nil {
+		return nil, nil, errors.New("scheme not found")
+	}
+
+	if err := ensureSchemeEligibility(s.Store, *scheme, time.Now()); err != nil {
+		return nil, nil, errors.New("failed to compute scheme eligibility")
+	}
+
+	applicants, total, err := s.Store.Eligibility().ListEligibleApplicants(schemeID, excludeApplied, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, nil, errors.New("failed to retrieve applicants")
+	}
+
+	now := time.Now()
+	eligibleApplicants := make([]dto.EligibleApplicant, len(applicants))
+	for i, applicant := range applicants {
+		entitlements, total := computeEntitlements(applicant, scheme.Benefits, now)
+		eligibleApplicants[i] = dto.EligibleApplicant{
+			ApplicantWithHousehold: dto.ApplicantWithHouseholdFromModel(applicant),
+			Entitlements:           entitlements,
+			TotalAmount:            total,
+		}
+	}
+
+	return eligibleApplicants, &dto.Pagination{
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
+}
+
+/* Helper Functions */
+
+// addSchemeVersion snapshots the current terms of a scheme. The unique (scheme_id, version)
+// index makes the later of two concurrent updates fail instead of overwriting a version.
+func addSchemeVersion(tx repository.Store, scheme models.Scheme, actor string) error {
+	version := models.SchemeVersion{
+		ID:                  utils.GenerateUUID(),
+		SchemeID:            scheme.ID,
+		Version:             scheme.Version,
+		Name:                scheme.Name,
+		Criteria:            scheme.Criteria,
+		Currency:            scheme.Currency,
+		Benefits:            models.BenefitList(scheme.Benefits),
+		EffectiveFrom:       scheme.EffectiveFrom,
+		EffectiveTo:         scheme.EffectiveTo,
+		ApplicationDeadline: scheme.ApplicationDeadline,
+		Budget:              scheme.Budget,
+		MaxRecipients:       scheme.MaxRecipients,
+		CreatedBy:           actor,
+		CreatedAt:           time.Now(),
+	}
+
+	if err := tx.Schemes().AddVersion(&version); err != nil {
+		return errors.New("failed to record scheme version")
+	}
+	return nil
+}
+
+// schemeOpenOn reports whether date (YYYY-MM-DD) falls within the scheme's effective period
+func schemeOpenOn(scheme models.Scheme, date string) bool {
+	if scheme.EffectiveFrom != "" && date < scheme.EffectiveFrom {
+		return false
+	}
+	return scheme.EffectiveTo == "" || date <= scheme.EffectiveTo
+}
+
+// checkApplicationWindow returns a SchemeClosedError when the scheme does not accept applications made on date
+func checkApplicationWindow(scheme models.Scheme, date string) error {
+	switch {
+	case scheme.EffectiveFrom != "" && date < scheme.EffectiveFrom:
+		return &SchemeClosedError{Reason: "scheme opens on " + scheme.EffectiveFrom}
+	case scheme.EffectiveTo != "" && date > scheme.EffectiveTo:
+		return &SchemeClosedError{Reason: "scheme closed on " + scheme.EffectiveTo}
+	case scheme.ApplicationDeadline != "" && date > scheme.ApplicationDeadline:
+		return &SchemeClosedError{Reason: "application deadline was " + scheme.ApplicationDeadline}
+	}
+	return nil
+}
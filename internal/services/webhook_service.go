@@ -0,0 +1,389 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/dto"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/repository"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/utils"
+)
+
+type WebhookService struct {
+	Store  repository.Store
+	Client *http.Client
+}
+
+func NewWebhookService(store repository.Store) *WebhookService {
+	return &WebhookService{
+		Store: store,
+		Client: &http.Client{
+			Timeout: data.WEBHOOK_TIMEOUT,
+			// A redirect is a failed delivery, the subscription should be updated instead
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+/* Service Functions */
+
+// CREATE Webhook Subscription. The secret is generated when none is given, and only returned here.
+func (s *WebhookService) CreateSubscription(input dto.WebhookSubscriptionInput, actor string) (*models.WebhookSubscription, string, error) {
+	if err := utils.ValidateWebhookSubscription(input.URL, input.EventTypes, input.Secret); err != nil {
+		return nil, "", err
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		generated, err := utils.GenerateSecret()
+		if err != nil {
+			return nil, "", errors.New("failed to generate webhook secret")
+		}
+		secret = generated
+	}
+
+	now := time.Now()
+	subscription := models.WebhookSubscription{
+		ID:         utils.GenerateUUID(),
+		URL:        input.URL,
+		EventTypes: input.EventTypes,
+		Secret:     secret,
+		Active:     input.Active == nil || *input.Active,
+		CreatedBy:  actor,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		if err := tx.Webhooks().CreateSubscription(&subscription); err != nil {
+			return errors.New("failed to create webhook")
+		}
+
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_WEBHOOK, subscription.ID, data.AUDIT_ACTION_CREATE, nil, subscription)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &subscription, secret, nil
+}
+
+// RETRIEVE All Webhook Subscriptions
+func (s *WebhookService) GetSubscriptions() ([]models.WebhookSubscription, error) {
+	subscriptions, err := s.Store.Webhooks().ListSubscriptions()
+	if err != nil {
+		return nil, errors.New("failed to retrieve webhooks")
+	}
+	return subscriptions, nil
+}
+
+// RETRIEVE Webhook Subscription by ID
+func (s *WebhookService) GetSubscription(id string) (*models.WebhookSubscription, error) {
+	subscription, err := s.Store.Webhooks().FindSubscription(id)
+	if err != nil {
+		return nil, errors.New("webhook not found")
+	}
+	return subscription, nil
+}
+
+// UDPATE Webhook Subscription. An empty secret keeps the current one.
+func (s *WebhookService) UpdateSubscription(id string, input dto.WebhookSubscriptionInput, actor string) (*models.WebhookSubscription, error) {
+	if err := utils.ValidateWebhookSubscription(input.URL, input.EventTypes, input.Secret); err != nil {
+		return nil, err
+	}
+
+	var output models.WebhookSubscription
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		subscription, err := tx.Webhooks().FindSubscription(id)
+		if err != nil {
+			return errors.New("webhook not found")
+		}
+		before := *subscription
+
+		subscription.URL = input.URL
+		subscription.EventTypes = input.EventTypes
+		if input.Secret != "" {
+			subscription.Secret = input.Secret
+		}
+		if input.Active != nil {
+			subscription.Active = *input.Active
+		}
+		subscription.UpdatedAt = time.Now()
+
+		if err := tx.Webhooks().UpdateSubscription(subscription); err != nil {
+			return errors.New("failed to update webhook")
+		}
+
+		output = *subscription
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_WEBHOOK, id, data.AUDIT_ACTION_UPDATE, before, output)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// DELETE Webhook Subscription. Its queued deliveries are given up when they are next attempted.
+func (s *WebhookService) DeleteSubscription(id, actor string) error {
+	return s.Store.Transaction(func(tx repository.Store) error {
+		subscription, err := tx.Webhooks().FindSubscription(id)
+		if err != nil {
+			return errors.New("webhook not found")
+		}
+
+		if err := tx.Webhooks().DeleteSubscription(id); err != nil {
+			return errors.New("failed to delete webhook")
+		}
+
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_WEBHOOK, id, data.AUDIT_ACTION_DELETE, *subscription, nil)
+	})
+}
+
+// RETRIEVE Webhook Deliveries, optionally filtered by Subscription, Status and Event Type
+func (s *WebhookService) GetDeliveries(filter dto.WebhookDeliveryFilter, opts dto.ListOptions) ([]models.WebhookDelivery, *dto.CursorPagination, error) {
+	if err := utils.ValidateSortField(opts.Sort, data.WEBHOOK_DELIVERY_SORT_FIELDS); err != nil {
+		return nil, nil, err
+	}
+
+	if filter.Status != "" && !data.WEBHOOK_DELIVERY_STATUSES[filter.Status] {
+		return nil, nil, fmt.Errorf("invalid delivery status: '%s'", filter.Status)
+	}
+
+	deliveries, next, err := s.Store.Webhooks().ListDeliveryPage(filter, opts)
+	if err != nil {
+		return nil, nil, listError(err, "failed to retrieve deliveries")
+	}
+
+	return deliveries, &dto.CursorPagination{Limit: opts.Limit, NextCursor: next}, nil
+}
+
+// RETRIEVE Webhook Delivery by ID
+func (s *WebhookService) GetDelivery(id string) (*models.WebhookDelivery, error) {
+	delivery, err := s.Store.Webhooks().FindDelivery(id)
+	if err != nil {
+		return nil, errors.New("delivery not found")
+	}
+	return delivery, nil
+}
+
+// UDPATE Webhook Delivery to be sent again with the same payload, with a fresh set of attempts
+func (s *WebhookService) RedeliverDelivery(id, actor string) (*models.WebhookDelivery, error) {
+	var output models.WebhookDelivery
+	err := s.Store.Transaction(func(tx repository.Store) error {
+		delivery, err := tx.Webhooks().FindDelivery(id)
+		if err != nil {
+			return errors.New("delivery not found")
+		}
+
+		if delivery.Status == data.WEBHOOK_DELIVERY_PENDING {
+			return errors.New("delivery is already pending")
+		}
+
+		if _, err := tx.Webhooks().FindSubscription(delivery.SubscriptionID); err != nil {
+			return errors.New("webhook of the delivery not found")
+		}
+		before := *delivery
+
+		now := time.Now()
+		delivery.Status = data.WEBHOOK_DELIVERY_PENDING
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = now
+		delivery.DeliveredAt = nil
+		delivery.UpdatedAt = now
+
+		if err := tx.Webhooks().UpdateDelivery(delivery); err != nil {
+			return errors.New("failed to redeliver")
+		}
+
+		output = *delivery
+		return recordAudit(tx, actor, data.AUDIT_ENTITY_WEBHOOK_DELIVERY, id, data.AUDIT_ACTION_STATUS_CHANGE, before, output)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &output, nil
+}
+
+// GENERATE Attempts of the pending deliveries that are due, oldest first. A failed delivery is retried
+// after a delay that doubles with every attempt, and is dead after data.WEBHOOK_MAX_ATTEMPTS.
+func (s *WebhookService) DeliverDue(ctx context.Context) (*dto.WebhookRun, error) {
+	run := &dto.WebhookRun{}
+	for ctx.Err() == nil {
+		now := time.Now()
+		deliveries, err := s.Store.Webhooks().ListDueDeliveries(now, data.WEBHOOK_BATCH_SIZE)
+		if err != nil {
+			return run, errors.New("failed to retrieve due deliveries")
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		for _, delivery := range deliveries {
+			claimed, err := s.Store.Webhooks().ClaimDelivery(delivery.ID, now, now.Add(data.WEBHOOK_LEASE))
+			if err != nil {
+				return run, errors.New("failed to claim delivery")
+			}
+			if !claimed {
+				continue
+			}
+
+			s.attempt(ctx, &delivery)
+			switch delivery.Status {
+			case data.WEBHOOK_DELIVERY_DELIVERED:
+				run.Delivered++
+			case data.WEBHOOK_DELIVERY_DEAD:
+				run.Dead++
+			default:
+				run.Retrying++
+			}
+
+			if err := s.Store.Webhooks().UpdateDelivery(&delivery); err != nil {
+				return run, errors.New("failed to record delivery attempt")
+			}
+		}
+
+		if len(deliveries) < data.WEBHOOK_BATCH_SIZE {
+			break
+		}
+	}
+
+	return run, nil
+}
+
+// RunDeliveryWorker delivers due webhooks now and then every interval until ctx is cancelled
+func (s *WebhookService) RunDeliveryWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := s.DeliverDue(ctx)
+		if err != nil {
+			log.Printf("[ERROR] Webhook delivery worker: %v", err)
+		} else if run.Retrying > 0 || run.Dead > 0 {
+			log.Printf("Webhook delivery worker delivered %d webhooks, %d will be retried, %d are dead", run.Delivered, run.Retrying, run.Dead)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/* Helper Functions */
+
+// attempt sends a claimed delivery once and records the outcome on it
+func (s *WebhookService) attempt(ctx context.Context, delivery *models.WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+
+	statusCode, err := s.send(ctx, delivery)
+	delivery.LastStatusCode = statusCode
+	if err == nil {
+		delivery.Status = data.WEBHOOK_DELIVERY_DELIVERED
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if len(delivery.LastError) > data.WEBHOOK_MAX_ERROR_LENGTH {
+		delivery.LastError = delivery.LastError[:data.WEBHOOK_MAX_ERROR_LENGTH]
+	}
+
+	if delivery.Attempts >= data.WEBHOOK_MAX_ATTEMPTS || errors.Is(err, repository.ErrNotFound) {
+		delivery.Status = data.WEBHOOK_DELIVERY_DEAD
+		return
+	}
+	delivery.NextAttemptAt = now.Add(retryDelay(delivery.Attempts-1, data.WEBHOOK_RETRY_BASE, data.WEBHOOK_RETRY_MAX))
+}
+
+// send posts the payload of a delivery to its subscription's URL, signed with the subscription's secret.
+// Any response other than 2xx is a failure.
+func (s *WebhookService) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	subscription, err := s.Store.Webhooks().FindSubscription(delivery.SubscriptionID)
+	if err != nil {
+		return 0, fmt.Errorf("webhook was deleted: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(data.WEBHOOK_HEADER_EVENT, delivery.EventType)
+	request.Header.Set(data.WEBHOOK_HEADER_EVENT_ID, delivery.EventID)
+	request.Header.Set(data.WEBHOOK_HEADER_DELIVERY, delivery.ID)
+	request.Header.Set(data.WEBHOOK_HEADER_TIMESTAMP, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(data.WEBHOOK_HEADER_SIGNATURE, utils.SignWebhook(subscription.Secret, timestamp, delivery.Payload))
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("receiver responded with status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// queueWebhookDeliveries adds a delivery of the event for every active subscription to its type
+func queueWebhookDeliveries(store repository.Store, event dto.Event, payload []byte) error {
+	subscriptions, err := store.Webhooks().ListSubscriptions()
+	if err != nil {
+		return errors.New("failed to retrieve webhooks")
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Active || !subscribedTo(subscription, event.Type) {
+			continue
+		}
+
+		now := time.Now()
+		delivery := models.WebhookDelivery{
+			ID:             utils.GenerateUUID(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         data.WEBHOOK_DELIVERY_PENDING,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		if _, err := store.Webhooks().AddDelivery(&delivery); err != nil {
+			return errors.New("failed to queue webhook delivery")
+		}
+	}
+
+	return nil
+}
+
+func subscribedTo(subscription models.WebhookSubscription, eventType string) bool {
+	for _, pattern := range subscription.EventTypes {
+		if utils.MatchEventType(pattern, eventType) {
+			return true
+		}
+	}
+	return false
+}
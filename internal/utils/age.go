@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// AgeOn returns the age in completed years of someone born on dateOfBirth
+// (YYYY-MM-DD) at the reference date asOf
+func AgeOn(dateOfBirth string, asOf time.Time) (int, error) {
+	dob, err := time.Parse(DateLayout, dateOfBirth)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date of birth: %s", dateOfBirth)
+	}
+
+	age := asOf.Year() - dob.Year()
+	if asOf.Month() < dob.Month() || (asOf.Month() == dob.Month() && asOf.Day() < dob.Day()) {
+		age--
+	}
+
+	return age, nil
+}
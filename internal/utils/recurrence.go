@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
+	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/models"
+)
+
+// Installment is one payment in a benefit's schedule, numbered from 1
+type Installment struct {
+	Number  int
+	DueDate string
+}
+
+// InstallmentSchedule returns the installments of a benefit for an application approved on approvedOn (YYYY-MM-DD).
+// A benefit without a recurrence is one installment due on the approval date. Installments of a recurrence
+// that fall before the approval date are not paid and are left out, keeping the numbers of the rest.
+func InstallmentSchedule(recurrence *models.Recurrence, approvedOn string) []Installment {
+	if recurrence == nil {
+		return []Installment{{Number: 1, DueDate: approvedOn}}
+	}
+
+	start := recurrence.StartDate
+	if start == "" {
+		start = approvedOn
+	}
+
+	anchor, err := time.Parse(DateLayout, start)
+	if err != nil {
+		return nil
+	}
+
+	installments := []Installment{}
+	for number := 1; number <= data.MAX_BENEFIT_INSTALLMENTS; number++ {
+		if recurrence.Installments > 0 && number > recurrence.Installments {
+			break
+		}
+
+		dueDate := installmentDate(anchor, recurrence.Frequency, number-1).Format(DateLayout)
+		if recurrence.EndDate != "" && dueDate > recurrence.EndDate {
+			break
+		}
+
+		// YYYY-MM-DD dates compare correctly as strings
+		if dueDate >= approvedOn {
+			installments = append(installments, Installment{Number: number, DueDate: dueDate})
+		}
+	}
+
+	return installments
+}
+
+// installmentDate is the due date periods frequencies after anchor. Calendar frequencies keep the
+// anchor's day of the month, or use the last day of shorter months, so the 31st stays at month end.
+func installmentDate(anchor time.Time, frequency string, periods int) time.Time {
+	if days, ok := data.BENEFIT_FREQUENCY_DAYS_MAP[frequency]; ok {
+		return anchor.AddDate(0, 0, days*periods)
+	}
+
+	months := data.BENEFIT_FREQUENCY_MONTHS_MAP[frequency] * periods
+	month := time.Date(anchor.Year(), anchor.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := month.AddDate(0, 1, -1).Day()
+
+	return time.Date(month.Year(), month.Month(), min(anchor.Day(), lastDay), 0, 0, 0, 0, time.UTC)
+}
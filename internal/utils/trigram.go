@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Thresholds used by the Postgres pg_trgm operators % and <%
+const (
+	SimilarityThreshold     = 0.3
+	WordSimilarityThreshold = 0.6
+)
+
+// trigrams splits text into lower case words and returns their trigrams in order,
+// padding each word like pg_trgm ("  w", " wo", "wor", "ord", "rd ").
+func trigrams(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var result []string
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result = append(result, string(padded[i:i+3]))
+		}
+	}
+	return result
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+// setSimilarity is the number of shared trigrams divided by the number of distinct trigrams
+func setSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range a {
+		if b[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// TrigramSimilarity mirrors pg_trgm's similarity(a, b)
+func TrigramSimilarity(a, b string) float64 {
+	return setSimilarity(toSet(trigrams(a)), toSet(trigrams(b)))
+}
+
+// WordSimilarity approximates pg_trgm's word_similarity(query, text): the best similarity
+// between the query and any continuous run of the trigrams of text
+func WordSimilarity(query, text string) float64 {
+	queryTrigrams := toSet(trigrams(query))
+	textTrigrams := trigrams(text)
+
+	best := 0.0
+	for start := range textTrigrams {
+		extent := map[string]bool{}
+		for end := start; end < len(textTrigrams); end++ {
+			extent[textTrigrams[end]] = true
+			if similarity := setSimilarity(queryTrigrams, extent); similarity > best {
+				best = similarity
+			}
+		}
+	}
+	return best
+}
+
+// NameMatchScore scores how well a name matches a search query, 0 when it does not match
+func NameMatchScore(query, name string) float64 {
+	similarity := TrigramSimilarity(query, name)
+	wordSimilarity := WordSimilarity(query, name)
+
+	if similarity < SimilarityThreshold && wordSimilarity < WordSimilarityThreshold {
+		return 0
+	}
+
+	if wordSimilarity > similarity {
+		return wordSimilarity
+	}
+	return similarity
+}
@@ -7,3 +7,8 @@ import (
 func GenerateUUID() string {
 	return uuid.New().String()
 }
+
+// GenerateNameUUID returns the same UUID for the same name, so a record keyed by it is created at most once
+func GenerateNameUUID(name string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
@@ -3,7 +3,9 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/MonokumeType01/Financial-Assistance-Scheme-Management-System/internal/data"
@@ -91,42 +93,397 @@ func ValidateApplicant(name, employmentStatus, sex, dateOfBirth string) error {
 
 /* Schemes Validation */
 
-func ValidateScheme(name, employmentStatus string, hasChildren *models.Children) error {
+func ValidateScheme(name string, criteria models.Criteria) error {
 	if name == "" {
 		return errors.New("scheme name cannot be empty")
 	}
 
-	validEmploymentStatus := map[string]bool{
-		"employed":   true,
-		"unemployed": true,
+	if criteria.Rule != nil {
+		return ValidateCriteriaNode(*criteria.Rule, 1)
+	}
+
+	return nil
+}
+
+// ValidateSchemeWindow checks the optional effective period and application deadline of a scheme
+func ValidateSchemeWindow(effectiveFrom, effectiveTo, applicationDeadline string) error {
+	dates := []struct {
+		field string
+		value string
+	}{
+		{"effective_from", effectiveFrom},
+		{"effective_to", effectiveTo},
+		{"application_deadline", applicationDeadline},
+	}
+	for _, date := range dates {
+		if date.value == "" {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, date.value); err != nil {
+			return fmt.Errorf("invalid %s, expected YYYY-MM-DD", date.field)
+		}
+	}
+
+	// YYYY-MM-DD dates compare correctly as strings
+	if effectiveFrom != "" && effectiveTo != "" && effectiveTo < effectiveFrom {
+		return errors.New("effective_to cannot be before effective_from")
+	}
+
+	if applicationDeadline != "" {
+		if effectiveFrom != "" && applicationDeadline < effectiveFrom {
+			return errors.New("application_deadline cannot be before effective_from")
+		}
+		if effectiveTo != "" && applicationDeadline > effectiveTo {
+			return errors.New("application_deadline cannot be after effective_to")
+		}
+	}
+
+	return nil
+}
+
+// ValidateSchemeCaps checks the optional budget and recipient limit of a scheme
+func ValidateSchemeCaps(budget *models.Money, maxRecipients *int) error {
+	if budget != nil && *budget < 0 {
+		return errors.New("budget cannot be negative")
+	}
+
+	if budget != nil && *budget > data.MAX_SCHEME_BUDGET {
+		return fmt.Errorf("budget cannot exceed %s", models.Money(data.MAX_SCHEME_BUDGET))
 	}
-	if employmentStatus != "" && !validEmploymentStatus[employmentStatus] {
-		return errors.New("invalid employment status, must be 'employed' or 'unemployed'")
+
+	if maxRecipients != nil && *maxRecipients < 0 {
+		return errors.New("max_recipients cannot be negative")
+	}
+
+	return nil
+}
+
+const (
+	maxCriteriaDepth = 10
+	maxCriteriaAge   = 150
+)
+
+func ValidateCriteriaNode(node models.CriteriaNode, depth int) error {
+	if depth > maxCriteriaDepth {
+		return fmt.Errorf("criteria cannot be nested more than %d levels deep", maxCriteriaDepth)
 	}
 
-	if hasChildren != nil {
-		_, isValidSchoolLevel := data.SCHOOL_LEVEL_TYPE_ID_MAP[hasChildren.SchoolLevel]
-		if !isValidSchoolLevel {
+	isGroup := node.Type == data.CRITERIA_NODE_AND || node.Type == data.CRITERIA_NODE_OR || node.Type == data.CRITERIA_NODE_NOT
+	if !isGroup && len(node.Nodes) > 0 {
+		return fmt.Errorf("'%s' criteria cannot contain nested nodes", node.Type)
+	}
+
+	switch node.Type {
+	case data.CRITERIA_NODE_AND, data.CRITERIA_NODE_OR:
+		if len(node.Nodes) == 0 {
+			return fmt.Errorf("'%s' criteria must contain at least one node", node.Type)
+		}
+
+	case data.CRITERIA_NODE_NOT:
+		if len(node.Nodes) != 1 {
+			return errors.New("'not' criteria must contain exactly one node")
+		}
+
+	case data.CRITERIA_NODE_EMPLOYMENT_STATUS:
+		validEmploymentStatus := map[string]bool{
+			"employed":   true,
+			"unemployed": true,
+		}
+		if !validEmploymentStatus[node.Value] {
+			return errors.New("invalid employment status, must be 'employed' or 'unemployed'")
+		}
+
+	case data.CRITERIA_NODE_SEX:
+		if node.Value != "male" && node.Value != "female" {
+			return errors.New("invalid sex, must be 'male' or 'female'")
+		}
+
+	case data.CRITERIA_NODE_HAS_RELATION:
+		if err := ValidateRelation(node.Value); err != nil {
+			return err
+		}
+
+	case data.CRITERIA_NODE_HAS_CHILDREN:
+		if _, isValidSchoolLevel := data.SCHOOL_LEVEL_TYPE_ID_MAP[node.SchoolLevel]; !isValidSchoolLevel {
 			return errors.New("invalid school level provided")
 		}
 
-		_, isValidCondition := data.CRITERIA_TYPE_ID_MAP[hasChildren.SchoolLevelCondition]
-		if !isValidCondition {
+		if _, isValidCondition := data.CRITERIA_TYPE_ID_MAP[node.Condition]; !isValidCondition {
 			return errors.New("invalid school level condition provided")
 		}
+
+	case data.CRITERIA_NODE_HOUSEHOLD_SIZE:
+		if _, isValidCondition := data.CRITERIA_TYPE_ID_MAP[node.Condition]; !isValidCondition {
+			return errors.New("invalid household size condition provided")
+		}
+
+		if node.Count < 0 {
+			return errors.New("household size cannot be negative")
+		}
+
+	case data.CRITERIA_NODE_APPLICANT_AGE, data.CRITERIA_NODE_MEMBER_AGE:
+		if _, isValidCondition := data.CRITERIA_TYPE_ID_MAP[node.Condition]; !isValidCondition {
+			return errors.New("invalid age condition provided")
+		}
+
+		if node.Age < 0 || node.Age > maxCriteriaAge {
+			return fmt.Errorf("age must be between 0 and %d", maxCriteriaAge)
+		}
+
+		if node.Type == data.CRITERIA_NODE_MEMBER_AGE && node.Value != "" {
+			if err := ValidateRelation(node.Value); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("unknown criteria type: '%s'", node.Type)
+	}
+
+	for _, child := range node.Nodes {
+		if err := ValidateCriteriaNode(child, depth+1); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func ValidateBenefit(name string, amount float64) error {
-	if name == "" {
+// ValidateBenefit checks a benefit with its optional recurrence and amount formula.
+// A benefit with a formula may have a zero base amount.
+func ValidateBenefit(benefit models.Benefit) error {
+	if benefit.Name == "" {
 		return errors.New("benefit name cannot be empty")
 	}
 
-	if amount <= 0 {
+	if benefit.Amount < 0 || (benefit.Amount == 0 && benefit.Formula == nil) {
 		return errors.New("benefit amount must be greater than zero")
 	}
 
+	if err := validateAmountLimit("benefit amount", benefit.Amount); err != nil {
+		return err
+	}
+
+	if benefit.Recurrence != nil {
+		if err := ValidateRecurrence(*benefit.Recurrence); err != nil {
+			return err
+		}
+	}
+
+	if benefit.Formula != nil {
+		if err := ValidateAmountFormula(*benefit.Formula); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateAmountFormula checks the rules that add to a benefit's amount per household member
+func ValidateAmountFormula(formula models.AmountFormula) error {
+	if len(formula.Rules) == 0 {
+		return errors.New("amount formula must contain at least one rule")
+	}
+
+	for i, rule := range formula.Rules {
+		if err := validateAmountRule(rule); err != nil {
+			return fmt.Errorf("amount formula rule %d: %v", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func validateAmountRule(rule models.AmountRule) error {
+	if err := ValidateMemberCriteria(rule.Member); err != nil {
+		return err
+	}
+
+	if rule.MaxMembers < 0 {
+		return errors.New("max_members cannot be negative")
+	}
+
+	if len(rule.Tiers) == 0 {
+		if rule.Amount <= 0 {
+			return errors.New("amount must be greater than zero")
+		}
+		return validateAmountLimit("amount", rule.Amount)
+	}
+
+	if rule.Amount != 0 {
+		return errors.New("set either amount or tiers, not both")
+	}
+
+	for i, tier := range rule.Tiers {
+		if err := ValidateMemberCriteria(tier.Member); err != nil {
+			return fmt.Errorf("tier %d: %v", i+1, err)
+		}
+		if tier.Amount <= 0 {
+			return fmt.Errorf("tier %d: amount must be greater than zero", i+1)
+		}
+		if err := validateAmountLimit(fmt.Sprintf("tier %d amount", i+1), tier.Amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateMemberCriteria checks a criteria node that selects household members. Only household member
+// predicates, and groups of them, can be evaluated against a single member.
+func ValidateMemberCriteria(node models.CriteriaNode) error {
+	if err := ValidateCriteriaNode(node, 1); err != nil {
+		return err
+	}
+
+	return validateMemberNodeTypes(node)
+}
+
+func validateMemberNodeTypes(node models.CriteriaNode) error {
+	switch node.Type {
+	case data.CRITERIA_NODE_AND, data.CRITERIA_NODE_OR, data.CRITERIA_NODE_NOT:
+		for _, child := range node.Nodes {
+			if err := validateMemberNodeTypes(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case data.CRITERIA_NODE_HAS_RELATION, data.CRITERIA_NODE_HAS_CHILDREN, data.CRITERIA_NODE_MEMBER_AGE:
+		return nil
+	default:
+		return fmt.Errorf("'%s' criteria cannot select household members", node.Type)
+	}
+}
+
+func validateAmountLimit(field string, amount models.Money) error {
+	if amount > data.MAX_BENEFIT_AMOUNT {
+		return fmt.Errorf("%s cannot exceed %s", field, models.Money(data.MAX_BENEFIT_AMOUNT))
+	}
+	return nil
+}
+
+// ValidateRecurrence checks the installment schedule of a recurring benefit
+func ValidateRecurrence(recurrence models.Recurrence) error {
+	_, fixed := data.BENEFIT_FREQUENCY_DAYS_MAP[recurrence.Frequency]
+	_, calendar := data.BENEFIT_FREQUENCY_MONTHS_MAP[recurrence.Frequency]
+	if !fixed && !calendar {
+		return fmt.Errorf("invalid recurrence frequency '%s'", recurrence.Frequency)
+	}
+
+	for _, date := range []struct{ field, value string }{
+		{"start_date", recurrence.StartDate},
+		{"end_date", recurrence.EndDate},
+	} {
+		if date.value == "" {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, date.value); err != nil {
+			return fmt.Errorf("invalid recurrence %s, expected YYYY-MM-DD", date.field)
+		}
+	}
+
+	if recurrence.Installments < 0 {
+		return errors.New("recurrence installments cannot be negative")
+	}
+
+	if recurrence.Installments > data.MAX_BENEFIT_INSTALLMENTS {
+		return fmt.Errorf("recurrence cannot have more than %d installments", data.MAX_BENEFIT_INSTALLMENTS)
+	}
+
+	if recurrence.Installments == 0 && recurrence.EndDate == "" {
+		return errors.New("recurrence needs a number of installments or an end_date")
+	}
+
+	if recurrence.StartDate != "" && recurrence.EndDate != "" && recurrence.EndDate < recurrence.StartDate {
+		return errors.New("recurrence end_date cannot be before start_date")
+	}
+
+	return nil
+}
+
+// ValidateCurrency checks that code is a supported ISO 4217 currency
+func ValidateCurrency(code string) error {
+	if !data.CURRENCY_CODES[code] {
+		return fmt.Errorf("unsupported currency '%s'", code)
+	}
+
+	return nil
+}
+
+/* Webhook Validation */
+
+// ValidateWebhookSubscription checks the target URL, the event type patterns and, when set, the secret
+func ValidateWebhookSubscription(target string, eventTypes []string, secret string) error {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid webhook url '%s', expected an absolute http or https URL", target)
+	}
+
+	if len(eventTypes) == 0 {
+		return errors.New("webhook must subscribe to at least one event type")
+	}
+
+	for _, eventType := range eventTypes {
+		if err := validateEventTypePattern(eventType); err != nil {
+			return err
+		}
+	}
+
+	if secret != "" && len(secret) < data.WEBHOOK_MIN_SECRET_LENGTH {
+		return fmt.Errorf("webhook secret must be at least %d characters", data.WEBHOOK_MIN_SECRET_LENGTH)
+	}
+
+	return nil
+}
+
+func validateEventTypePattern(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+
+	entityType, action, _ := strings.Cut(pattern, ".")
+	if data.AUDIT_ENTITY_TYPES[entityType] {
+		if action == "*" {
+			return nil
+		}
+		for _, eventAction := range data.EVENT_ACTION_MAP {
+			if action == eventAction {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("invalid event type '%s'", pattern)
+}
+
+/* Application Validation */
+
+func ValidateStatusTransition(fromStatus, toStatus string) error {
+	for _, allowed := range data.APPLICATION_STATUS_TRANSITION_MAP[fromStatus] {
+		if allowed == toStatus {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid status transition from '%s' to '%s'", fromStatus, toStatus)
+}
+
+func ValidateLedgerTransition(fromType, toType string) error {
+	for _, allowed := range data.LEDGER_ENTRY_TRANSITION_MAP[fromType] {
+		if allowed == toType {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("a %s disbursement cannot be marked %s", fromType, toType)
+}
+
+/* List Validation */
+
+func ValidateSortField(field string, allowed map[string]bool) error {
+	if !allowed[field] {
+		return fmt.Errorf("invalid sort field: '%s'", field)
+	}
+
 	return nil
 }
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// SignWebhook returns the signature header value of a webhook body sent at timestamp (Unix seconds)
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook reports whether signature is the signature of body sent at timestamp, in constant time
+func VerifyWebhook(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signature))
+}
+
+// GenerateSecret returns a random hex encoded secret of 32 bytes
+func GenerateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// MatchEventType reports whether an event type is selected by pattern: "*" for every event,
+// "application.*" for every event of an entity type, or an exact type such as "application.status_changed"
+func MatchEventType(pattern, eventType string) bool {
+	if pattern == "*" || pattern == eventType {
+		return true
+	}
+
+	entityType, ok := strings.CutSuffix(pattern, ".*")
+	return ok && strings.HasPrefix(eventType, entityType+".")
+}